@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// patientsLockClass is the first key of the Postgres advisory locks of patients, the second one is the patient id.
+// Archiving a patient holds the lock exclusively, writing a task of a patient holds it shared, see lockPatient.
+const patientsLockClass = 7_326_449
+
+// PatientArchive defines a schema of the patients whose tasks were archived, e.g. because they were deleted.
+// No tasks can be created for them until they are restored.
+type PatientArchive struct {
+	bun.BaseModel `bun:"table:patient_archives"`
+
+	PatientId  int32     `bun:",pk"`
+	Reason     string    `bun:",notnull"`
+	ArchivedBy string    `bun:",notnull"`
+	ArchivedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// TaskArchive defines a schema of the tasks that were cancelled because their patient was deleted.
+// It remembers the status each task had, so that it can be restored if the patient is restored.
+type TaskArchive struct {
+	bun.BaseModel `bun:"table:task_archives"`
+
+	TaskId         int32      `bun:",pk"`
+	PatientId      int32      `bun:",notnull"`
+	PreviousStatus TaskStatus `bun:",notnull"`
+	// ArchivedVersion is the version of the task once it was cancelled, zero for tasks archived before it was kept.
+	ArchivedVersion int64     `bun:",nullzero"`
+	Reason          string    `bun:",notnull"`
+	ArchivedBy      string    `bun:",notnull"`
+	ArchivedAt      time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// unchanged reports whether the task wasn't changed since it was archived, so it can be restored.
+// Tasks archived before their version was kept are restored if they are still cancelled.
+func (archive TaskArchive) unchanged(task *Task) bool {
+	if archive.ArchivedVersion == 0 {
+		return task.Status == TaskStatusCancelled
+	}
+	return task.Version == archive.ArchivedVersion
+}
+
+// lockPatient locks the patient until tx ends, exclusively to archive or restore it, otherwise shared.
+func lockPatient(ctx context.Context, tx bun.Tx, patientId int32, exclusive bool) error {
+	query := "SELECT pg_advisory_xact_lock_shared(?, ?)"
+	if exclusive {
+		query = "SELECT pg_advisory_xact_lock(?, ?)"
+	}
+	if _, err := tx.ExecContext(ctx, query, patientsLockClass, patientId); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to lock a patient: %w", err).Error())
+	}
+	return nil
+}
+
+// checkPatientNotArchived returns codes.FailedPrecondition if the patient is archived.
+// The patient is locked until tx ends, so it can't be archived before the tasks written by tx are committed.
+func checkPatientNotArchived(ctx context.Context, tx bun.Tx, patientId int32) error {
+	if err := lockPatient(ctx, tx, patientId, false); err != nil {
+		return err
+	}
+	archived, err := tx.NewSelect().
+		Model((*PatientArchive)(nil)).
+		Where("? = ?", bun.Ident("patient_id"), patientId).
+		Exists(ctx)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to fetch a patient archive: %w", err).Error())
+	}
+	if archived {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("patient %d is archived", patientId))
+	}
+	return nil
+}
+
+// archivePatientTasks archives the patient and cancels all of their unfinished tasks on behalf of actor,
+// remembering the statuses of the tasks along with the reason. The ids of the cancelled tasks are returned.
+func archivePatientTasks(ctx context.Context, tx bun.Tx, patientId int32, reason string, actor string) (
+	[]int32, error) {
+	if err := lockPatient(ctx, tx, patientId, true); err != nil {
+		return nil, err
+	}
+	patientArchive := PatientArchive{PatientId: patientId, Reason: reason, ArchivedBy: actor}
+	if _, err := tx.NewInsert().
+		Model(&patientArchive).
+		On("CONFLICT (patient_id) DO UPDATE").
+		Set("reason = EXCLUDED.reason").
+		Set("archived_by = EXCLUDED.archived_by").
+		Set("archived_at = current_timestamp").
+		Exec(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to archive a patient: %w", err).Error())
+	}
+
+	var tasks []*Task
+	if err := tx.NewSelect().
+		Model(&tasks).
+		Where("? = ?", bun.Ident("patient_id"), patientId).
+		Where("? NOT IN (?)", bun.Ident("status"), bun.In([]TaskStatus{TaskStatusDone, TaskStatusCancelled})).
+		Order("id").
+		For("UPDATE").
+		Scan(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch tasks of a patient: %w", err).Error())
+	}
+
+	ids := make([]int32, len(tasks))
+	for i, task := range tasks {
+		previousStatus := task.Status
+		if err := transitionTask(ctx, tx, task, TaskStatusCancelled, actor, TaskAuditActionArchive); err != nil {
+			return nil, err
+		}
+		archive := TaskArchive{
+			TaskId:          task.Id,
+			PatientId:       patientId,
+			PreviousStatus:  previousStatus,
+			ArchivedVersion: task.Version,
+			Reason:          reason,
+			ArchivedBy:      actor,
+		}
+		if _, err := tx.NewInsert().
+			Model(&archive).
+			On("CONFLICT (task_id) DO UPDATE").
+			Set("previous_status = EXCLUDED.previous_status").
+			Set("archived_version = EXCLUDED.archived_version").
+			Set("reason = EXCLUDED.reason").
+			Set("archived_by = EXCLUDED.archived_by").
+			Set("archived_at = current_timestamp").
+			Exec(ctx); err != nil {
+			return nil, status.Error(codes.Internal, fmt.Errorf("failed to archive a task: %w", err).Error())
+		}
+		ids[i] = task.Id
+	}
+	return ids, nil
+}
+
+// unarchivePatientTasks restores the patient and moves their archived tasks back to the statuses they had
+// on behalf of actor. Tasks that were changed or deleted since they were archived are left as they are.
+// A task that can't get back to its status, because some of its prerequisites are not finished anymore,
+// is reopened instead. The ids of the restored tasks are returned.
+func unarchivePatientTasks(ctx context.Context, tx bun.Tx, patientId int32, actor string) ([]int32, error) {
+	if err := lockPatient(ctx, tx, patientId, true); err != nil {
+		return nil, err
+	}
+	if _, err := tx.NewDelete().
+		Model((*PatientArchive)(nil)).
+		Where("? = ?", bun.Ident("patient_id"), patientId).
+		Exec(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to restore a patient: %w", err).Error())
+	}
+
+	var archives []TaskArchive
+	if err := tx.NewDelete().
+		Model(&archives).
+		Where("? = ?", bun.Ident("patient_id"), patientId).
+		Returning("*").
+		Scan(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch archived tasks: %w", err).Error())
+	}
+	if len(archives) == 0 {
+		return nil, nil
+	}
+	archivesByTask := make(map[int32]TaskArchive, len(archives))
+	archivedIds := make([]int32, len(archives))
+	for i, archive := range archives {
+		archivesByTask[archive.TaskId] = archive
+		archivedIds[i] = archive.TaskId
+	}
+
+	var tasks []*Task
+	if err := tx.NewSelect().
+		Model(&tasks).
+		Where("? IN (?)", bun.Ident("id"), bun.In(archivedIds)).
+		Order("id").
+		For("UPDATE").
+		Scan(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch archived tasks: %w", err).Error())
+	}
+
+	var ids []int32
+	for _, task := range tasks {
+		archive := archivesByTask[task.Id]
+		if !archive.unchanged(task) {
+			continue
+		}
+		// the task is moved straight back, the transition from cancelled isn't one a user could make
+		err := moveTask(ctx, tx, task, archive.PreviousStatus, actor, TaskAuditActionUnarchive)
+		if status.Code(err) == codes.FailedPrecondition && archive.PreviousStatus != TaskStatusOpen {
+			err = moveTask(ctx, tx, task, TaskStatusOpen, actor, TaskAuditActionUnarchive)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, task.Id)
+	}
+	return ids, nil
+}
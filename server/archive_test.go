@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestTaskArchiveUnchanged(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive TaskArchive
+		task    Task
+		want    bool
+	}{
+		{
+			name:    "unchanged",
+			archive: TaskArchive{PreviousStatus: TaskStatusInProgress, ArchivedVersion: 4},
+			task:    Task{Status: TaskStatusCancelled, Version: 4},
+			want:    true,
+		},
+		{
+			name:    "edited while cancelled",
+			archive: TaskArchive{PreviousStatus: TaskStatusInProgress, ArchivedVersion: 4},
+			task:    Task{Status: TaskStatusCancelled, Version: 5},
+		},
+		{
+			name:    "reopened",
+			archive: TaskArchive{PreviousStatus: TaskStatusOpen, ArchivedVersion: 4},
+			task:    Task{Status: TaskStatusOpen, Version: 5},
+		},
+		{
+			name:    "archived before versions were kept",
+			archive: TaskArchive{PreviousStatus: TaskStatusBlocked},
+			task:    Task{Status: TaskStatusCancelled, Version: 9},
+			want:    true,
+		},
+		{
+			name:    "archived before versions were kept and reopened",
+			archive: TaskArchive{PreviousStatus: TaskStatusBlocked},
+			task:    Task{Status: TaskStatusOpen, Version: 9},
+		},
+	}
+	for _, test := range tests {
+		if got := test.archive.unchanged(&test.task); got != test.want {
+			t.Errorf("%s: unchanged = %t, want %t", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+	"github.com/uptrace/bun"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Actions recorded in the audit trail of a task.
+const (
+	TaskAuditActionCreate  = "create"
+	TaskAuditActionUpdate  = "update"
+	TaskAuditActionDelete  = "delete"
+	TaskAuditActionRestore = "restore"
+	TaskAuditActionPurge   = "purge"
+	// TaskAuditActionArchive and TaskAuditActionUnarchive record tasks cancelled and restored with their patient.
+	TaskAuditActionArchive   = "archive"
+	TaskAuditActionUnarchive = "unarchive"
+)
+
+// TaskAuditEntry is an append-only record of a change of a task.
+// Entries are kept after the task is purged, so they don't reference the tasks table.
+type TaskAuditEntry struct {
+	bun.BaseModel `bun:"table:task_audit_log"`
+
+	Id     int64  `bun:",pk,autoincrement"`
+	TaskId int32  `bun:",notnull"`
+	Action string `bun:",notnull"`
+	Actor  string `bun:",notnull"`
+	// Changes maps names of the changed task fields, as named in the GRPC version, to their values.
+	Changes   map[string]taskFieldChange `bun:"type:jsonb,notnull"`
+	CreatedAt time.Time                  `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// taskFieldChange is a value of a task field before and after a change.
+// Values are nil if the field is unset.
+type taskFieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// toGRPC returns a GRPC version of TaskAuditEntry.
+func (entry TaskAuditEntry) toGRPC() (*ppb.TaskHistoryEntry, error) {
+	fields := make([]string, 0, len(entry.Changes))
+	for field := range entry.Changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	changes := make([]*ppb.TaskFieldChange, 0, len(fields))
+	for _, field := range fields {
+		change := entry.Changes[field]
+		before, err := structpb.NewValue(change.Before)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert a value of %s: %w", field, err)
+		}
+		after, err := structpb.NewValue(change.After)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert a value of %s: %w", field, err)
+		}
+		changes = append(changes, &ppb.TaskFieldChange{Field: field, Before: before, After: after})
+	}
+	return &ppb.TaskHistoryEntry{
+		Id:        entry.Id,
+		TaskId:    entry.TaskId,
+		Action:    entry.Action,
+		Actor:     entry.Actor,
+		Changes:   changes,
+		CreatedAt: formatTimestamp(entry.CreatedAt),
+	}, nil
+}
+
+// taskFields returns the fields of the GRPC version of a task as JSON values, omitting unset fields.
+// A nil task has no fields.
+func taskFields(task *Task) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if task == nil {
+		return fields, nil
+	}
+	raw, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(task.toGRPC())
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// taskChanges returns the fields that differ between two states of a task.
+// A nil before means the task was created, a nil after means it was removed.
+func taskChanges(before, after *Task) (map[string]taskFieldChange, error) {
+	beforeFields, err := taskFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := taskFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]taskFieldChange{}
+	for field, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[field]) {
+			changes[field] = taskFieldChange{Before: value, After: afterFields[field]}
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = taskFieldChange{Before: nil, After: value}
+		}
+	}
+	return changes, nil
+}
+
+// reloadTask fetches the current state of a task with the given id in the transaction, whether it is deleted or not.
+func reloadTask(ctx context.Context, tx bun.Tx, id int32) (*Task, error) {
+	task := new(Task)
+	if err := tx.NewSelect().
+		Model(task).
+		Where("? = ?", bun.Ident("id"), id).
+		WhereAllWithDeleted().
+		Scan(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch a task by id: %w", err).Error())
+	}
+	return task, nil
+}
+
+// recordTaskAudit appends an entry with the changes between two states of a task to its audit trail.
+// Nothing is appended if the states don't differ.
+// It has to be called in the transaction that makes the change, so the change and its record are committed together.
+func recordTaskAudit(ctx context.Context, tx bun.Tx, action string, actor string, before, after *Task) error {
+	var taskId int32
+	if after != nil {
+		taskId = after.Id
+	} else {
+		taskId = before.Id
+	}
+	changes, err := taskChanges(before, after)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to compute task changes: %w", err).Error())
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	entry := TaskAuditEntry{
+		TaskId:  taskId,
+		Action:  action,
+		Actor:   actor,
+		Changes: changes,
+	}
+	if _, err = tx.NewInsert().Model(&entry).Exec(ctx); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to record a task audit entry: %w", err).Error())
+	}
+	return nil
+}
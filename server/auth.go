@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	ms "github.com/TekClinic/MicroService-Lib"
+)
+
+var errMissingSubject = errors.New("token has no subject")
+
+// subjectClaims are claims that carry the subject of the token.
+type subjectClaims interface {
+	GetSubject() string
+}
+
+// claimsSubject returns the subject (the user id) of verified claims.
+// The claims of MicroService-Lib carry only roles, so unless they implement subjectClaims the subject
+// is read from the payload of the token the claims were verified from.
+func claimsSubject(claims ms.Claims, token string) (string, error) {
+	if withSubject, ok := claims.(subjectClaims); ok {
+		if subject := withSubject.GetSubject(); subject != "" {
+			return subject, nil
+		}
+		return "", errMissingSubject
+	}
+	return tokenSubject(token)
+}
+
+// tokenSubject returns the subject of a JWT token.
+// The token signature is not checked here, so it must be called only by claimsSubject for a verified token.
+func tokenSubject(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	const jwtParts = 3
+	if len(parts) != jwtParts {
+		return "", errors.New("token is malformed")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("failed to decode token payload: %w", err)
+	}
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("failed to parse token payload: %w", err)
+	}
+	if claims.Subject == "" {
+		return "", errMissingSubject
+	}
+	return claims.Subject, nil
+}
+
+// callerContextKey is the context key of the verified caller of an RPC.
+type callerContextKey struct{}
+
+// caller is the verified identity of the caller of an RPC, stored in the context by the auth interceptors.
+type caller struct {
+	claims  ms.Claims
+	subject string
+	token   string
+}
+
+// withCaller returns a copy of ctx that carries the verified caller.
+func withCaller(ctx context.Context, c caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, c)
+}
+
+// callerClaims returns the claims of the verified caller of an RPC.
+func callerClaims(ctx context.Context) ms.Claims {
+	c, _ := ctx.Value(callerContextKey{}).(caller)
+	return c.claims
+}
+
+// callerToken returns the verified token of the caller of an RPC, to be forwarded to other services.
+func callerToken(ctx context.Context) string {
+	c, _ := ctx.Value(callerContextKey{}).(caller)
+	return c.token
+}
+
+// callerSubject returns the subject (the user id) of the verified caller of an RPC.
+func callerSubject(ctx context.Context) (string, error) {
+	c, ok := ctx.Value(callerContextKey{}).(caller)
+	if !ok {
+		return "", errMissingToken
+	}
+	return c.subject, nil
+}
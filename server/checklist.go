@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+	"github.com/uptrace/bun"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ChecklistItem defines a schema of checklist items, the steps that a task consists of.
+type ChecklistItem struct {
+	bun.BaseModel `bun:"table:task_checklist_items"`
+
+	Id     int32  `bun:",pk,autoincrement"`
+	TaskId int32  `bun:",notnull"`
+	Title  string `bun:",notnull" validate:"required,min=1,max=100"`
+	Done   bool   `bun:",notnull"`
+	// Position orders the items of a task, items with equal positions are ordered by id.
+	Position  int32     `bun:",notnull"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// toGRPC returns a GRPC version of ChecklistItem.
+func (item ChecklistItem) toGRPC() *ppb.ChecklistItem {
+	return &ppb.ChecklistItem{
+		Id:       item.Id,
+		TaskId:   item.TaskId,
+		Title:    item.Title,
+		Done:     item.Done,
+		Position: item.Position,
+	}
+}
+
+// checklistProgress returns the percentage of done checklist items of a task.
+// A task without a checklist is either not started or fully done.
+func checklistProgress(task Task) int32 {
+	if len(task.ChecklistItems) == 0 {
+		if task.Complete {
+			return 100
+		}
+		return 0
+	}
+	var done int
+	for _, item := range task.ChecklistItems {
+		if item.Done {
+			done++
+		}
+	}
+	return int32(done * 100 / len(task.ChecklistItems))
+}
+
+// checklistItemUpdateFromMask returns the columns described by the field mask paths of UpdateChecklistItemRequest.
+// An empty mask updates all the columns.
+func checklistItemUpdateFromMask(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return []string{"title", "done", "position"}, nil
+	}
+	columns := make([]string, 0, len(paths))
+	for _, path := range paths {
+		switch path {
+		case "title", "done", "position":
+			if !slices.Contains(columns, path) {
+				columns = append(columns, path)
+			}
+		default:
+			return nil, fmt.Errorf("field %q can't be updated", path)
+		}
+	}
+	return columns, nil
+}
+
+// loadChecklists fetches the checklist items of the given tasks, ordered by their position.
+func loadChecklists(ctx context.Context, db bun.IDB, tasks ...*Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	tasksByID := make(map[int32]*Task, len(tasks))
+	ids := make([]int32, len(tasks))
+	for i, task := range tasks {
+		task.ChecklistItems = nil
+		tasksByID[task.Id] = task
+		ids[i] = task.Id
+	}
+
+	var items []ChecklistItem
+	if err := db.NewSelect().
+		Model(&items).
+		Where("? IN (?)", bun.Ident("task_id"), bun.In(ids)).
+		Order("position", "id").
+		Scan(ctx); err != nil {
+		return err
+	}
+	for _, item := range items {
+		task := tasksByID[item.TaskId]
+		task.ChecklistItems = append(task.ChecklistItems, item)
+	}
+	return nil
+}
+
+// syncTaskWithChecklist completes the task once all its checklist items are done,
+// and reopens a completed task once it has an item that is not done.
+// The task has to be locked by tx. Tasks that can't move to done, like blocked ones or the ones
+// with unfinished prerequisites, are left as is.
+func syncTaskWithChecklist(ctx context.Context, tx bun.Tx, task *Task, actor string) error {
+	if err := loadChecklists(ctx, tx, task); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to fetch checklist items: %w", err).Error())
+	}
+	if len(task.ChecklistItems) == 0 {
+		return nil
+	}
+	allDone := checklistProgress(*task) == 100
+	switch {
+	case allDone && task.Status != TaskStatusDone && canTransition(task.Status, TaskStatusDone):
+		if err := loadDependencies(ctx, tx, task); err != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to fetch task dependencies: %w", err).Error())
+		}
+		if len(task.BlockedBy) > 0 {
+			return nil
+		}
+		return transitionTask(ctx, tx, task, TaskStatusDone, actor, TaskAuditActionUpdate)
+	case !allDone && task.Status == TaskStatusDone:
+		return transitionTask(ctx, tx, task, TaskStatusOpen, actor, TaskAuditActionUpdate)
+	default:
+		return nil
+	}
+}
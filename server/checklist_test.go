@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestChecklistProgress(t *testing.T) {
+	tests := []struct {
+		name string
+		task Task
+		want int32
+	}{
+		{name: "open without a checklist", task: Task{}, want: 0},
+		{name: "complete without a checklist", task: Task{Complete: true}, want: 100},
+		{name: "nothing done", task: Task{ChecklistItems: []ChecklistItem{{}, {}}}, want: 0},
+		{name: "half done", task: Task{ChecklistItems: []ChecklistItem{{Done: true}, {}}}, want: 50},
+		{name: "rounded down", task: Task{ChecklistItems: []ChecklistItem{{Done: true}, {}, {}}}, want: 33},
+		{name: "all done", task: Task{ChecklistItems: []ChecklistItem{{Done: true}, {Done: true}}}, want: 100},
+		{
+			name: "checklist wins over the complete flag",
+			task: Task{Complete: true, ChecklistItems: []ChecklistItem{{Done: true}, {}, {}, {}}},
+			want: 25,
+		},
+	}
+	for _, test := range tests {
+		if got := checklistProgress(test.task); got != test.want {
+			t.Errorf("%s: checklistProgress = %d, want %d", test.name, got, test.want)
+		}
+	}
+}
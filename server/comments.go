@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+	"github.com/uptrace/bun"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TaskComment defines a schema of comments on tasks.
+type TaskComment struct {
+	bun.BaseModel `bun:"table:task_comments"`
+
+	Id     int32 `bun:",pk,autoincrement"`
+	TaskId int32 `bun:",notnull"`
+	// Author is the subject of the user that wrote the comment.
+	Author string `bun:",notnull"`
+	Body   string `bun:",notnull" validate:"required,min=1,max=2000"`
+	// EditedAt is zero if the comment was never edited.
+	EditedAt  time.Time `bun:",nullzero"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	DeletedAt time.Time `bun:",soft_delete,nullzero"`
+}
+
+// toGRPC returns a GRPC version of TaskComment.
+func (comment TaskComment) toGRPC() *ppb.Comment {
+	return &ppb.Comment{
+		Id:        comment.Id,
+		TaskId:    comment.TaskId,
+		Author:    comment.Author,
+		Body:      comment.Body,
+		CreatedAt: formatTimestamp(comment.CreatedAt),
+		EditedAt:  formatTimestamp(comment.EditedAt),
+	}
+}
+
+// loadCommentCounts fetches the number of comments of the given tasks.
+func loadCommentCounts(ctx context.Context, db bun.IDB, tasks ...*Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	tasksByID := make(map[int32]*Task, len(tasks))
+	ids := make([]int32, len(tasks))
+	for i, task := range tasks {
+		task.CommentCount = 0
+		tasksByID[task.Id] = task
+		ids[i] = task.Id
+	}
+
+	var counts []struct {
+		TaskId int32
+		Count  int32
+	}
+	if err := db.NewSelect().
+		Model((*TaskComment)(nil)).
+		Column("task_id").
+		ColumnExpr("count(*) AS count").
+		Where("? IN (?)", bun.Ident("task_id"), bun.In(ids)).
+		Group("task_id").
+		Scan(ctx, &counts); err != nil {
+		return err
+	}
+	for _, count := range counts {
+		tasksByID[count.TaskId].CommentCount = count.Count
+	}
+	return nil
+}
+
+// fetchAccessibleTask fetches a task with the given id that the caller is allowed to read.
+// Callers without the tasks:read permission can only read the tasks assigned to them.
+// If a task with a given id doesn't exist, codes.NotFound is returned.
+// If the caller is not allowed to read it, codes.PermissionDenied is returned.
+func (server tasksServer) fetchAccessibleTask(ctx context.Context, id int32) (*Task, error) {
+	task := new(Task)
+	if err := server.db.NewSelect().
+		Model(task).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "task is not found")
+		}
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch a task by id: %w", err).Error())
+	}
+	if server.permissions.allows(callerClaims(ctx), permissionRead) {
+		return task, nil
+	}
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if task.Assignee != subject {
+		return nil, status.Error(codes.PermissionDenied, permissionDeniedMessage)
+	}
+	return task, nil
+}
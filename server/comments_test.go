@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestTaskCommentToGRPC(t *testing.T) {
+	createdAt := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		comment      TaskComment
+		wantEditedAt string
+	}{
+		{
+			name:    "never edited",
+			comment: TaskComment{Id: 1, TaskId: 2, Author: "alice", Body: "Called", CreatedAt: createdAt},
+		},
+		{
+			name: "edited",
+			comment: TaskComment{Id: 1, TaskId: 2, Author: "alice", Body: "Called twice", CreatedAt: createdAt,
+				EditedAt: createdAt.Add(time.Hour)},
+			wantEditedAt: "2025-01-01T13:00:00Z",
+		},
+	}
+	for _, test := range tests {
+		got := test.comment.toGRPC()
+		if got.GetId() != test.comment.Id || got.GetTaskId() != test.comment.TaskId ||
+			got.GetAuthor() != test.comment.Author || got.GetBody() != test.comment.Body {
+			t.Errorf("%s: toGRPC = %v, want the fields of %+v", test.name, got, test.comment)
+		}
+		if got.GetCreatedAt() != "2025-01-01T12:00:00Z" {
+			t.Errorf("%s: created at %q, want 2025-01-01T12:00:00Z", test.name, got.GetCreatedAt())
+		}
+		if got.GetEditedAt() != test.wantEditedAt {
+			t.Errorf("%s: edited at %q, want %q", test.name, got.GetEditedAt(), test.wantEditedAt)
+		}
+	}
+}
+
+func TestTaskCommentValidation(t *testing.T) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{name: "short", body: "x"},
+		{name: "longest", body: strings.Repeat("x", 2000)},
+		{name: "empty", wantErr: true},
+		{name: "too long", body: strings.Repeat("x", 2001), wantErr: true},
+	}
+	for _, test := range tests {
+		comment := TaskComment{TaskId: 1, Author: "alice", Body: test.body}
+		if err := validate.Struct(comment); (err != nil) != test.wantErr {
+			t.Errorf("%s: Struct = %v, want an error: %t", test.name, err, test.wantErr)
+		}
+		// EditComment validates the body only
+		if err := validate.StructPartial(TaskComment{Body: test.body}, "Body"); (err != nil) != test.wantErr {
+			t.Errorf("%s: StructPartial = %v, want an error: %t", test.name, err, test.wantErr)
+		}
+	}
+}
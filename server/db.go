@@ -2,41 +2,94 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	// TODO: ppb is probably short for ppb. Rename to tasks_pb, tpb, or just pb.
 	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
 	"github.com/uptrace/bun"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-const yyyy_mm_dd = "2006-01-02"
-//comment added
+const (
+	yyyy_mm_dd      = "2006-01-02"
+	timestampFormat = time.RFC3339
+)
+
+// comment added
 // Task defines a schema of tasks.
 // TODO: Check the tags, we don't actually understand what they do.
 type Task struct {
-	Id                int32               `bun:",pk,autoincrement" `
-	Complete          bool                ``
-	Title             string              `validate:"required,min=1,max=100"`
-	Description       string              ``
-	Expertise         string              ``
-    PatientId         int32               ``
-	SpecialNote       string              `validate:"max=500"`
-    // These are automatically populated by bun
-	CreatedAt         time.Time           `bun:",nullzero,notnull,default:current_timestamp"`
-	DeletedAt         time.Time           `bun:",soft_delete,nullzero"`
+	Id          int32  `bun:",pk,autoincrement" `
+	Complete    bool   ``
+	Title       string `validate:"required,min=1,max=100"`
+	Description string ``
+	Expertise   string ``
+	PatientId   int32  `validate:"min=1"`
+	SpecialNote string `validate:"max=500"`
+	// Assignee is the subject of the user working on the task. Empty if nobody is assigned.
+	Assignee   string    `bun:",nullzero"`
+	AssignedAt time.Time `bun:",nullzero"`
+	// Complete is derived from Status and kept for clients that don't know about statuses.
+	Status          TaskStatus   `bun:",nullzero,notnull,default:'open'"`
+	StatusChangedAt time.Time    `bun:",nullzero"`
+	StatusChangedBy string       `bun:",nullzero"`
+	DueAt           time.Time    `bun:",nullzero"`
+	Priority        TaskPriority `bun:",notnull,default:2"`
+	// Version is incremented on every change of the task, so that concurrent changes can be detected.
+	Version int64 `bun:",nullzero,notnull,default:1"`
+	// TemplateId and OccurrenceAt identify the occurrence of the template the task was created from, if any.
+	TemplateId   int32     `bun:",nullzero"`
+	OccurrenceAt time.Time `bun:",nullzero"`
+	// ChecklistItems and CommentCount are stored in separate tables, they are fetched with loadTaskDetails.
+	ChecklistItems []ChecklistItem `bun:"-"`
+	CommentCount   int32           `bun:"-"`
+	// DependsOn and BlockedBy are ids of the prerequisites of the task, they are fetched with loadDependencies.
+	DependsOn []int32 `bun:"-"`
+	BlockedBy []int32 `bun:"-"`
+	// These are automatically populated by bun
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	DeletedAt time.Time `bun:",soft_delete,nullzero"`
 }
 
 // toGRPC returns a GRPC version of Task.
 func (task Task) toGRPC() *ppb.Task {
+	var assignedAt string
+	if !task.AssignedAt.IsZero() {
+		assignedAt = task.AssignedAt.Format(yyyy_mm_dd)
+	}
+	checklist := make([]*ppb.ChecklistItem, len(task.ChecklistItems))
+	for i, item := range task.ChecklistItems {
+		checklist[i] = item.toGRPC()
+	}
 	return &ppb.Task{
-		Id:                task.Id,
-		Complete:          task.Complete,
-        Title:             task.Title,
-        Description:       task.Description,
-        Expertise:         task.Expertise,
-        PatientId:         task.PatientId,
-        CreatedAt:         task.CreatedAt.Format(yyyy_mm_dd),
+		Id:              task.Id,
+		Complete:        task.Complete,
+		Title:           task.Title,
+		Description:     task.Description,
+		Expertise:       task.Expertise,
+		PatientId:       task.PatientId,
+		CreatedAt:       task.CreatedAt.Format(yyyy_mm_dd),
+		Assignee:        task.Assignee,
+		AssignedAt:      assignedAt,
+		Status:          task.Status.toGRPC(),
+		StatusChangedAt: formatTimestamp(task.StatusChangedAt),
+		StatusChangedBy: task.StatusChangedBy,
+		DueAt:           formatTimestamp(task.DueAt),
+		Priority:        task.Priority.toGRPC(),
+		DeletedAt:       formatTimestamp(task.DeletedAt),
+		SpecialNote:     task.SpecialNote,
+		Version:         task.Version,
+		Checklist:       checklist,
+		Progress:        checklistProgress(task),
+		DependsOn:       task.DependsOn,
+		BlockedBy:       task.BlockedBy,
+		TemplateId:      task.TemplateId,
+		CommentCount:    task.CommentCount,
 	}
 }
 
@@ -54,57 +107,193 @@ func taskFromGRPC(task *ppb.Task) (Task, error) {
 	if err != nil {
 		return Task{}, fmt.Errorf("failed to parse task creation date: %w", err)
 	}
+	dueAt, err := parseTimestamp(task.GetDueAt())
+	if err != nil {
+		return Task{}, fmt.Errorf("failed to parse task due date: %w", err)
+	}
+	priority, err := taskPriorityFromGRPC(task.GetPriority())
+	if err != nil {
+		return Task{}, err
+	}
 	return Task{
-		Id:                task.GetId(),
-		Complete:          task.GetComplete(),
-        Title:             task.GetTitle(),
-        Description:       task.GetDescription(),
-        Expertise:         task.GetExpertise(),
-        PatientId:         task.GetPatientId(),
-        CreatedAt:         created_at,
+		Id:          task.GetId(),
+		Complete:    task.GetComplete(),
+		Title:       task.GetTitle(),
+		Description: task.GetDescription(),
+		Expertise:   task.GetExpertise(),
+		PatientId:   task.GetPatientId(),
+		SpecialNote: task.GetSpecialNote(),
+		CreatedAt:   created_at,
+		DueAt:       dueAt,
+		Priority:    priority,
+		Version:     task.GetVersion(),
 	}, nil
 }
 
-// createSchemaIfNotExists creates all required schemas for task microservice.
-func createSchemaIfNotExists(ctx context.Context, db *bun.DB) error {
-	models := []interface{}{
-		(*Task)(nil),
-	}
+// taskScope selects tasks by whether they are deleted.
+type taskScope int
+
+const (
+	// taskScopeActive selects the tasks that are not deleted.
+	taskScopeActive taskScope = iota
+	// taskScopeDeleted selects the deleted tasks only.
+	taskScopeDeleted
+	// taskScopeAll selects both deleted and not deleted tasks.
+	taskScopeAll
+)
 
-	for _, model := range models {
-		if _, err := db.NewCreateTable().IfNotExists().Model(model).Exec(ctx); err != nil {
-			return err
+// lockTask fetches a task with the given id within the scope and locks it until tx ends.
+// If a task with a given id doesn't exist in the scope, codes.NotFound is returned.
+func lockTask(ctx context.Context, tx bun.Tx, id int32, scope taskScope) (*Task, error) {
+	task := new(Task)
+	query := tx.NewSelect().
+		Model(task).
+		Where("? = ?", bun.Ident("id"), id).
+		For("UPDATE")
+	notFoundMessage := "task is not found"
+	switch scope {
+	case taskScopeActive:
+	case taskScopeDeleted:
+		query = query.WhereDeleted()
+		notFoundMessage = "deleted task is not found"
+	case taskScopeAll:
+		query = query.WhereAllWithDeleted()
+	}
+	if err := query.Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, notFoundMessage)
 		}
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch a task by id: %w", err).Error())
+	}
+	return task, nil
+}
+
+// loadTaskDetails fetches the data of the given tasks that is stored in separate tables:
+// their checklist items and comment counts.
+func loadTaskDetails(ctx context.Context, db bun.IDB, tasks ...*Task) error {
+	if err := loadChecklists(ctx, db, tasks...); err != nil {
+		return fmt.Errorf("failed to fetch checklist items: %w", err)
+	}
+	if err := loadCommentCounts(ctx, db, tasks...); err != nil {
+		return fmt.Errorf("failed to count comments: %w", err)
 	}
+	return nil
+}
 
-    /* Copied code from patients microservice. Do we need to add deleted_at?
-	// Migration code. Add created_at and deleted_at columns to the task table for soft delete.
-	if _, err := db.NewRaw(
-		"ALTER TABLE tasks " +
-			"ADD COLUMN IF NOT EXISTS created_at timestamptz NOT NULL DEFAULT now(), " +
-			"ADD COLUMN IF NOT EXISTS deleted_at timestamptz, " +
-			"ADD COLUMN IF NOT EXISTS needs_translator BOOLEAN, " + // <-- Add the comma
-			"ALTER COLUMN needs_translator SET DEFAULT false;").Exec(ctx); err != nil {
+// insertTask inserts a new task on behalf of actor, records its creation in the task history and publishes it.
+// If the patient of the task is archived, codes.FailedPrecondition is returned.
+func insertTask(ctx context.Context, tx bun.Tx, task *Task, actor string) error {
+	if err := checkPatientNotArchived(ctx, tx, task.PatientId); err != nil {
 		return err
 	}
-    */
-
-    /* Search code. Also copied from patients microservice.
-	// Postgres specific code. Add a text_searchable column for full-text search.
-	if _, err := db.NewRaw(
-		"ALTER TABLE tasks " +
-			"ADD COLUMN IF NOT EXISTS text_searchable tsvector " +
-			"GENERATED ALWAYS AS " +
-			"(" +
-			"setweight(to_tsvector('simple', coalesce(personal_id_id, '')), 'A') || " +
-			"setweight(to_tsvector('simple', coalesce(phone_number, '')), 'A')   || " +
-			"setweight(to_tsvector('simple', coalesce(name, '')), 'B')           || " +
-			"setweight(to_tsvector('simple', coalesce(special_note, '')), 'C')   || " +
-			"setweight(to_tsvector('simple', coalesce(referred_by, '')), 'D')" +
-			") STORED").Exec(ctx); err != nil {
+	if _, err := tx.NewInsert().Model(task).Exec(ctx); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to create a task: %w", err).Error())
+	}
+	if err := publishTaskEvent(ctx, tx, task.Id, TaskEventCreated); err != nil {
 		return err
 	}
-    */
+	return recordTaskAudit(ctx, tx, TaskAuditActionCreate, actor, nil, task)
+}
 
+// taskUpdate describes which fields of a task are changed by UpdateTask.
+type taskUpdate struct {
+	// fields are names of the changed Task fields, used for validation.
+	fields []string
+	// columns are the changed columns that are updated as is.
+	columns []string
+	// complete is set if the complete flag is changed, which moves the task to another status.
+	complete bool
+	// replace is set if the mask is empty and the whole task is replaced.
+	replace bool
+}
+
+// taskUpdateFromMask returns the update described by the field mask paths of UpdateTaskRequest.
+// An empty mask updates all the fields, which replaces the whole task.
+func taskUpdateFromMask(paths []string) (taskUpdate, error) {
+	var update taskUpdate
+	if len(paths) == 0 {
+		paths = []string{"title", "description", "expertise", "patient_id", "special_note", "due_at", "priority",
+			"complete"}
+		update.replace = true
+	}
+	for _, path := range paths {
+		var field string
+		switch path {
+		case "title":
+			field = "Title"
+		case "description":
+			field = "Description"
+		case "expertise":
+			field = "Expertise"
+		case "patient_id":
+			field = "PatientId"
+		case "special_note":
+			field = "SpecialNote"
+		case "due_at":
+			field = "DueAt"
+		case "priority":
+			field = "Priority"
+		case "complete":
+			update.complete = true
+			continue
+		default:
+			return taskUpdate{}, fmt.Errorf("field %q can't be updated", path)
+		}
+		if !slices.Contains(update.fields, field) {
+			update.fields = append(update.fields, field)
+			update.columns = append(update.columns, path)
+		}
+	}
+	return update, nil
+}
+
+// merge fills the fields of task that clients leave unset with the ones of the current task.
+// If the due date is moved into the past, an error is returned.
+func (update taskUpdate) merge(task *Task, current *Task, now time.Time) error {
+	// clients that don't know about priorities don't send it, keep the current one
+	if task.Priority == 0 {
+		task.Priority = current.Priority
+	}
+	// clients that don't know about due dates and special notes replace the whole task without them
+	if update.replace {
+		if task.DueAt.IsZero() {
+			task.DueAt = current.DueAt
+		}
+		if task.SpecialNote == "" {
+			task.SpecialNote = current.SpecialNote
+		}
+	}
+	// a due date that already passed can be sent back as is, but a task can't be rescheduled into the past
+	if slices.Contains(update.fields, "DueAt") && !task.DueAt.IsZero() && !task.DueAt.Equal(current.DueAt) &&
+		task.DueAt.Before(now) {
+		return errors.New("task due date has to be in the future")
+	}
 	return nil
 }
+
+// parseTimestamp parses an optional RFC 3339 timestamp. An empty string is parsed as zero time.
+func parseTimestamp(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(timestampFormat, raw)
+}
+
+// formatTimestamp formats an optional timestamp as RFC 3339. Zero time is formatted as an empty string.
+func formatTimestamp(timestamp time.Time) string {
+	if timestamp.IsZero() {
+		return ""
+	}
+	return timestamp.Format(timestampFormat)
+}
+
+// TaskTransition defines a schema of task status changes.
+// A row is recorded every time a task moves from one status to another.
+type TaskTransition struct {
+	Id         int64      `bun:",pk,autoincrement"`
+	TaskId     int32      `bun:",notnull"`
+	FromStatus TaskStatus `bun:",notnull"`
+	ToStatus   TaskStatus `bun:",notnull"`
+	Actor      string     `bun:",notnull"`
+	CreatedAt  time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTaskUpdateFromMask(t *testing.T) {
+	tests := []struct {
+		name         string
+		paths        []string
+		wantFields   []string
+		wantColumns  []string
+		wantComplete bool
+		wantReplace  bool
+		wantErr      bool
+	}{
+		{
+			name:         "empty mask replaces the task",
+			wantFields:   []string{"Title", "Description", "Expertise", "PatientId", "SpecialNote", "DueAt", "Priority"},
+			wantColumns:  []string{"title", "description", "expertise", "patient_id", "special_note", "due_at", "priority"},
+			wantComplete: true,
+			wantReplace:  true,
+		},
+		{
+			name:        "some fields",
+			paths:       []string{"title", "due_at"},
+			wantFields:  []string{"Title", "DueAt"},
+			wantColumns: []string{"title", "due_at"},
+		},
+		{name: "complete only", paths: []string{"complete"}, wantComplete: true},
+		{
+			name:        "duplicates",
+			paths:       []string{"patient_id", "patient_id"},
+			wantFields:  []string{"PatientId"},
+			wantColumns: []string{"patient_id"},
+		},
+		{name: "assignee is changed by AssignTask", paths: []string{"assignee"}, wantErr: true},
+		{name: "status is changed by TransitionTask", paths: []string{"title", "status"}, wantErr: true},
+		{name: "version", paths: []string{"version"}, wantErr: true},
+	}
+	for _, test := range tests {
+		update, err := taskUpdateFromMask(test.paths)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: taskUpdateFromMask(%q) = %+v, want an error", test.name, test.paths, update)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: taskUpdateFromMask(%q) returned an error: %v", test.name, test.paths, err)
+			continue
+		}
+		if !slices.Equal(update.fields, test.wantFields) || !slices.Equal(update.columns, test.wantColumns) ||
+			update.complete != test.wantComplete || update.replace != test.wantReplace {
+			t.Errorf("%s: taskUpdateFromMask(%q) = %+v, want fields %q, columns %q, complete %t and replace %t",
+				test.name, test.paths, update, test.wantFields, test.wantColumns, test.wantComplete, test.wantReplace)
+		}
+	}
+}
+
+func TestTaskUpdateMerge(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	current := &Task{Priority: TaskPriorityHigh, DueAt: now.Add(-time.Hour), SpecialNote: "allergic"}
+	replace := taskUpdate{fields: []string{"Title", "SpecialNote", "DueAt", "Priority"}, replace: true}
+	masked := taskUpdate{fields: []string{"SpecialNote", "DueAt", "Priority"}}
+	tests := []struct {
+		name    string
+		update  taskUpdate
+		task    Task
+		want    Task
+		wantErr bool
+	}{
+		{
+			name:   "replace keeps unset fields",
+			update: replace,
+			task:   Task{Title: "new"},
+			want:   Task{Title: "new", Priority: TaskPriorityHigh, DueAt: current.DueAt, SpecialNote: "allergic"},
+		},
+		{
+			name:   "replace changes set fields",
+			update: replace,
+			task:   Task{Priority: TaskPriorityLow, DueAt: now.Add(time.Hour), SpecialNote: "none"},
+			want:   Task{Priority: TaskPriorityLow, DueAt: now.Add(time.Hour), SpecialNote: "none"},
+		},
+		{
+			name:   "mask clears fields",
+			update: masked,
+			task:   Task{},
+			want:   Task{Priority: TaskPriorityHigh},
+		},
+		{
+			name:   "passed due date is sent back",
+			update: masked,
+			task:   Task{DueAt: current.DueAt},
+			want:   Task{Priority: TaskPriorityHigh, DueAt: current.DueAt},
+		},
+		{name: "due date moved into the past", update: masked, task: Task{DueAt: now.Add(-time.Minute)}, wantErr: true},
+		{
+			name:   "due date is not updated",
+			update: taskUpdate{fields: []string{"Title"}},
+			task:   Task{DueAt: now.Add(-time.Minute)},
+			want:   Task{Priority: TaskPriorityHigh, DueAt: now.Add(-time.Minute)},
+		},
+	}
+	for _, test := range tests {
+		task := test.task
+		err := test.update.merge(&task, current, now)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: merge = %v, want an error: %t", test.name, err, test.wantErr)
+			continue
+		}
+		if test.wantErr {
+			continue
+		}
+		if task.Title != test.want.Title || task.Priority != test.want.Priority || !task.DueAt.Equal(test.want.DueAt) ||
+			task.SpecialNote != test.want.SpecialNote {
+			t.Errorf("%s: merged task = %+v, want %+v", test.name, task, test.want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dependenciesLockID is the key of the Postgres advisory lock held while a dependency is added,
+// so concurrent additions can't create a cycle that neither of them sees.
+const dependenciesLockID = 7_326_448_174
+
+// TaskDependency defines a schema of task dependencies: the task can't start until the prerequisite is finished.
+type TaskDependency struct {
+	bun.BaseModel `bun:"table:task_dependencies"`
+
+	TaskId      int32     `bun:",pk"`
+	DependsOnId int32     `bun:",pk"`
+	CreatedAt   time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// loadDependencies fetches the ids of the prerequisites of the task and of those that are not finished yet.
+// Prerequisites are finished once they are done or cancelled, deleted prerequisites are ignored.
+func loadDependencies(ctx context.Context, db bun.IDB, task *Task) error {
+	var prerequisites []Task
+	if err := db.NewSelect().
+		Model(&prerequisites).
+		Column("task.id", "task.status").
+		Join("JOIN task_dependencies AS dependency ON ? = ?",
+			bun.Ident("dependency.depends_on_id"), bun.Ident("task.id")).
+		Where("? = ?", bun.Ident("dependency.task_id"), task.Id).
+		Order("task.id").
+		Scan(ctx); err != nil {
+		return err
+	}
+
+	task.DependsOn = make([]int32, 0, len(prerequisites))
+	task.BlockedBy = nil
+	for _, prerequisite := range prerequisites {
+		task.DependsOn = append(task.DependsOn, prerequisite.Id)
+		if prerequisite.Status != TaskStatusDone && prerequisite.Status != TaskStatusCancelled {
+			task.BlockedBy = append(task.BlockedBy, prerequisite.Id)
+		}
+	}
+	return nil
+}
+
+// checkNotBlocked returns codes.FailedPrecondition if the task has prerequisites that are not finished yet.
+func checkNotBlocked(ctx context.Context, db bun.IDB, task *Task) error {
+	if err := loadDependencies(ctx, db, task); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to fetch task dependencies: %w", err).Error())
+	}
+	if len(task.BlockedBy) > 0 {
+		return status.Error(codes.FailedPrecondition,
+			fmt.Sprintf("task is blocked by unfinished tasks %v", task.BlockedBy))
+	}
+	return nil
+}
+
+// dependsOn reports whether a task depends on another one, directly or through other tasks.
+func dependsOn(ctx context.Context, tx bun.Tx, taskId int32, prerequisiteId int32) (bool, error) {
+	var exists bool
+	err := tx.NewRaw(
+		"WITH RECURSIVE prerequisites AS ("+
+			"SELECT depends_on_id FROM task_dependencies WHERE task_id = ? "+
+			"UNION "+
+			"SELECT dependency.depends_on_id FROM task_dependencies AS dependency "+
+			"JOIN prerequisites ON dependency.task_id = prerequisites.depends_on_id"+
+			") SELECT EXISTS (SELECT 1 FROM prerequisites WHERE depends_on_id = ?)",
+		taskId, prerequisiteId).
+		Scan(ctx, &exists)
+	return exists, err
+}
+
+// addDependency makes the task depend on the prerequisite.
+// If the prerequisite already depends on the task, directly or through other tasks, codes.InvalidArgument is returned.
+func addDependency(ctx context.Context, tx bun.Tx, taskId int32, prerequisiteId int32) error {
+	if taskId == prerequisiteId {
+		return status.Error(codes.InvalidArgument, "task can't depend on itself")
+	}
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(?)", dependenciesLockID); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to acquire dependencies lock: %w", err).Error())
+	}
+
+	cycle, err := dependsOn(ctx, tx, prerequisiteId, taskId)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to fetch task dependencies: %w", err).Error())
+	}
+	if cycle {
+		return status.Error(codes.InvalidArgument,
+			fmt.Sprintf("task %d already depends on task %d, the dependency would create a cycle", prerequisiteId, taskId))
+	}
+
+	dependency := TaskDependency{TaskId: taskId, DependsOnId: prerequisiteId}
+	if _, err = tx.NewInsert().Model(&dependency).On("CONFLICT DO NOTHING").Exec(ctx); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to add a task dependency: %w", err).Error())
+	}
+	return nil
+}
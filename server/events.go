@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// taskEventsChannel is the Postgres notification channel that new task events are announced on.
+	taskEventsChannel = "task_events"
+	// taskEventsRetention is how long task events are kept, watchers can resume only within it.
+	taskEventsRetention = 7 * 24 * time.Hour
+	// taskEventsPruneInterval is how often the events older than taskEventsRetention are removed.
+	taskEventsPruneInterval = time.Hour
+)
+
+// Types of task events, as stored in the database.
+const (
+	TaskEventCreated   = "created"
+	TaskEventUpdated   = "updated"
+	TaskEventCompleted = "completed"
+	TaskEventDeleted   = "deleted"
+	TaskEventRestored  = "restored"
+	TaskEventAssigned  = "assigned"
+)
+
+// TaskEvent defines a schema of task events, which are streamed to watchers by WatchTasks.
+// The fields that watchers filter by are copied from the task, so events can be filtered after the task is purged.
+type TaskEvent struct {
+	bun.BaseModel `bun:"table:task_events"`
+
+	Id int64 `bun:",pk,autoincrement"`
+	// XactId is the id of the transaction that published the event. Transactions don't commit in the order
+	// of their ids, so events are streamed by transaction only once all the earlier transactions ended.
+	XactId    int64  `bun:",type:xid8,nullzero,notnull,default:pg_current_xact_id()"`
+	TaskId    int32  `bun:",notnull"`
+	Type      string `bun:",notnull"`
+	PatientId int32
+	Assignee  string    `bun:",nullzero"`
+	Expertise string    `bun:",nullzero"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// toGRPC returns a GRPC version of TaskEvent.
+func (event TaskEvent) toGRPC() *ppb.TaskEvent {
+	return &ppb.TaskEvent{
+		Id:        event.Id,
+		TaskId:    event.TaskId,
+		Type:      taskEventTypeToGRPC(event.Type),
+		CreatedAt: formatTimestamp(event.CreatedAt),
+	}
+}
+
+// taskEventTypeToGRPC returns a GRPC version of a task event type.
+func taskEventTypeToGRPC(eventType string) ppb.TaskEvent_Type {
+	switch eventType {
+	case TaskEventCreated:
+		return ppb.TaskEvent_TYPE_CREATED
+	case TaskEventUpdated:
+		return ppb.TaskEvent_TYPE_UPDATED
+	case TaskEventCompleted:
+		return ppb.TaskEvent_TYPE_COMPLETED
+	case TaskEventDeleted:
+		return ppb.TaskEvent_TYPE_DELETED
+	case TaskEventRestored:
+		return ppb.TaskEvent_TYPE_RESTORED
+	case TaskEventAssigned:
+		return ppb.TaskEvent_TYPE_ASSIGNED
+	default:
+		return ppb.TaskEvent_TYPE_UNSPECIFIED
+	}
+}
+
+// taskEventTypeFromGRPC converts a GRPC task event type to the one stored in the database.
+func taskEventTypeFromGRPC(eventType ppb.TaskEvent_Type) (string, error) {
+	switch eventType {
+	case ppb.TaskEvent_TYPE_CREATED:
+		return TaskEventCreated, nil
+	case ppb.TaskEvent_TYPE_UPDATED:
+		return TaskEventUpdated, nil
+	case ppb.TaskEvent_TYPE_COMPLETED:
+		return TaskEventCompleted, nil
+	case ppb.TaskEvent_TYPE_DELETED:
+		return TaskEventDeleted, nil
+	case ppb.TaskEvent_TYPE_RESTORED:
+		return TaskEventRestored, nil
+	case ppb.TaskEvent_TYPE_ASSIGNED:
+		return TaskEventAssigned, nil
+	case ppb.TaskEvent_TYPE_UNSPECIFIED:
+		return "", errors.New("event type is not specified")
+	default:
+		return "", fmt.Errorf("unknown event type %d", eventType)
+	}
+}
+
+// publishTaskEvent records an event of a task with the given id and announces it to all replicas.
+// The task has to exist, it is read in tx along with the changes made so far.
+// The notification is sent only when tx commits, so watchers never see events of rolled back changes.
+// The event is also written to the outbox, to be delivered to other services.
+func publishTaskEvent(ctx context.Context, tx bun.Tx, taskId int32, eventType string) error {
+	if _, err := tx.ExecContext(ctx,
+		"WITH event AS ("+
+			"INSERT INTO task_events (task_id, type, patient_id, assignee, expertise) "+
+			"SELECT id, ?, patient_id, assignee, expertise FROM tasks WHERE id = ? "+
+			"RETURNING id"+
+			") SELECT pg_notify(?, id::text) FROM event",
+		eventType, taskId, taskEventsChannel); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to publish a task event: %w", err).Error())
+	}
+
+	task, err := reloadTask(ctx, tx, taskId)
+	if err != nil {
+		return err
+	}
+	if err = loadTaskDetails(ctx, tx, task); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return enqueueDomainEvent(ctx, tx, task, eventType)
+}
+
+// taskEventCursor is the position of a watcher in the stream of task events.
+// Events are streamed in the order of (XactId, Id).
+type taskEventCursor struct {
+	xactId int64
+	id     int64
+}
+
+// cursor returns the cursor positioned at the event.
+func (event TaskEvent) cursor() taskEventCursor {
+	return taskEventCursor{xactId: event.XactId, id: event.Id}
+}
+
+// currentTaskEventCursor returns the cursor positioned before the events of the transactions that are
+// still running, so that a new watcher streams only the events that are committed from now on.
+// The events of transactions that committed just before may be streamed as well.
+func currentTaskEventCursor(ctx context.Context, db bun.IDB) (taskEventCursor, error) {
+	var xmin int64
+	if err := db.NewSelect().
+		ColumnExpr("pg_snapshot_xmin(pg_current_snapshot())::text::bigint").
+		Scan(ctx, &xmin); err != nil {
+		return taskEventCursor{}, err
+	}
+	return taskEventCursor{xactId: xmin}, nil
+}
+
+// taskEventCursorAt returns the cursor positioned at the event with the given id.
+// If the event doesn't exist, because it was never published or was already removed, codes.OutOfRange is returned.
+func taskEventCursorAt(ctx context.Context, db bun.IDB, id int64) (taskEventCursor, error) {
+	event := new(TaskEvent)
+	if err := db.NewSelect().
+		Model(event).
+		Column("id", "xact_id").
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return taskEventCursor{}, status.Error(codes.OutOfRange,
+				"event to resume after is not found, it may be older than the events retention")
+		}
+		return taskEventCursor{}, status.Error(codes.Internal, fmt.Errorf("failed to fetch task events: %w", err).Error())
+	}
+	return event.cursor(), nil
+}
+
+// selectTaskEventsAfter narrows down the query to the events after the cursor whose transactions
+// and all the earlier ones have ended, so no event can show up before the cursor later on.
+func selectTaskEventsAfter(query *bun.SelectQuery, cursor taskEventCursor) *bun.SelectQuery {
+	return query.
+		Where("(?, ?) > (?::text::xid8, ?)", bun.Ident("xact_id"), bun.Ident("id"), cursor.xactId, cursor.id).
+		Where("? < pg_snapshot_xmin(pg_current_snapshot())", bun.Ident("xact_id")).
+		Order("xact_id", "id")
+}
+
+// selectHeldBackTaskEvents narrows down the query to the events after the cursor that selectTaskEventsAfter
+// holds back because an earlier transaction is still running, once they are older than maxHoldBack.
+// Only the events of committed transactions are visible, but they may come before the events of
+// the running transactions. Events are as old as their transactions, so the events of a short transaction
+// are held back for about maxHoldBack after it commits.
+func selectHeldBackTaskEvents(query *bun.SelectQuery, cursor taskEventCursor,
+	maxHoldBack time.Duration) *bun.SelectQuery {
+	return query.
+		Where("(?, ?) > (?::text::xid8, ?)", bun.Ident("xact_id"), bun.Ident("id"), cursor.xactId, cursor.id).
+		Where("? >= pg_snapshot_xmin(pg_current_snapshot())", bun.Ident("xact_id")).
+		Where("? < now() - make_interval(secs => ?)", bun.Ident("created_at"), maxHoldBack.Seconds()).
+		Order("xact_id", "id")
+}
+
+// taskEventWatch is the position of a watcher in the stream of task events: the cursor of the events
+// streamed in order, and the events after the cursor that were streamed ahead of it.
+type taskEventWatch struct {
+	cursor taskEventCursor
+	// ahead are the ids of the events that were sent ahead of the cursor, see selectHeldBackTaskEvents.
+	// They are removed once the cursor passes them, so they grow only while a transaction holds events back.
+	ahead map[int64]struct{}
+}
+
+// newTaskEventWatch returns a watch positioned at the cursor.
+func newTaskEventWatch(cursor taskEventCursor) *taskEventWatch {
+	return &taskEventWatch{cursor: cursor, ahead: map[int64]struct{}{}}
+}
+
+// advance moves the cursor to an event streamed in order and reports whether the event has to be sent,
+// which it doesn't if it was already sent ahead of the cursor.
+func (watch *taskEventWatch) advance(event TaskEvent) bool {
+	watch.cursor = event.cursor()
+	if _, ok := watch.ahead[event.Id]; ok {
+		delete(watch.ahead, event.Id)
+		return false
+	}
+	return true
+}
+
+// sendAhead records that an event was sent ahead of the cursor, so it isn't sent again once the cursor passes it.
+func (watch *taskEventWatch) sendAhead(event TaskEvent) {
+	watch.ahead[event.Id] = struct{}{}
+}
+
+// aheadIds returns the ids of the events that were sent ahead of the cursor, in ascending order.
+func (watch *taskEventWatch) aheadIds() []int64 {
+	ids := make([]int64, 0, len(watch.ahead))
+	for id := range watch.ahead {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// runTaskEventsPruner removes the task events older than taskEventsRetention until ctx is done.
+func runTaskEventsPruner(ctx context.Context, db *bun.DB) {
+	ticker := time.NewTicker(taskEventsPruneInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := db.NewDelete().
+			Model((*TaskEvent)(nil)).
+			Where("? < ?", bun.Ident("created_at"), time.Now().Add(-taskEventsRetention)).
+			Exec(ctx); err != nil {
+			zap.L().Error("Failed to remove old task events", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// taskEventHub wakes up the watchers of this replica when task events are announced by any replica.
+type taskEventHub struct {
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+// newTaskEventHub returns a hub without subscribers. It has to be started with listen.
+func newTaskEventHub() *taskEventHub {
+	return &taskEventHub{subscribers: map[chan struct{}]struct{}{}}
+}
+
+// subscribe returns a channel that receives a value when new events are announced, and a function that
+// cancels the subscription. Announcements that come while the subscriber is busy are merged into one.
+func (hub *taskEventHub) subscribe() (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+	hub.mu.Lock()
+	hub.subscribers[wake] = struct{}{}
+	hub.mu.Unlock()
+	return wake, func() {
+		hub.mu.Lock()
+		delete(hub.subscribers, wake)
+		hub.mu.Unlock()
+	}
+}
+
+// broadcast wakes up all the subscribers without waiting for them.
+func (hub *taskEventHub) broadcast() {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	for wake := range hub.subscribers {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// listen listens to task event announcements until ctx is done and wakes up the subscribers on each one.
+// The listener reconnects on its own if the connection is lost.
+func (hub *taskEventHub) listen(ctx context.Context, db *bun.DB) {
+	listener := pgdriver.NewListener(db)
+	defer listener.Close()
+	if err := listener.Listen(ctx, taskEventsChannel); err != nil {
+		zap.L().Error("Failed to listen to task events", zap.Error(err))
+	}
+	notifications := listener.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-notifications:
+			if !ok {
+				return
+			}
+			hub.broadcast()
+		}
+	}
+}
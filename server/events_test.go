@@ -0,0 +1,112 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+)
+
+func TestTaskEventTypeFromGRPC(t *testing.T) {
+	tests := []struct {
+		eventType ppb.TaskEvent_Type
+		want      string
+		wantErr   bool
+	}{
+		{eventType: ppb.TaskEvent_TYPE_CREATED, want: TaskEventCreated},
+		{eventType: ppb.TaskEvent_TYPE_UPDATED, want: TaskEventUpdated},
+		{eventType: ppb.TaskEvent_TYPE_COMPLETED, want: TaskEventCompleted},
+		{eventType: ppb.TaskEvent_TYPE_DELETED, want: TaskEventDeleted},
+		{eventType: ppb.TaskEvent_TYPE_RESTORED, want: TaskEventRestored},
+		{eventType: ppb.TaskEvent_TYPE_ASSIGNED, want: TaskEventAssigned},
+		{eventType: ppb.TaskEvent_TYPE_UNSPECIFIED, wantErr: true},
+		{eventType: ppb.TaskEvent_Type(100), wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := taskEventTypeFromGRPC(test.eventType)
+		if (err != nil) != test.wantErr {
+			t.Errorf("taskEventTypeFromGRPC(%s) = %v, want an error: %t", test.eventType, err, test.wantErr)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("taskEventTypeFromGRPC(%s) = %q, want %q", test.eventType, got, test.want)
+		}
+		if !test.wantErr && taskEventTypeToGRPC(got) != test.eventType {
+			t.Errorf("taskEventTypeToGRPC(%q) = %s, want %s", got, taskEventTypeToGRPC(got), test.eventType)
+		}
+	}
+	if got := taskEventTypeToGRPC("unknown"); got != ppb.TaskEvent_TYPE_UNSPECIFIED {
+		t.Errorf("taskEventTypeToGRPC of an unknown type = %s, want %s", got, ppb.TaskEvent_TYPE_UNSPECIFIED)
+	}
+}
+
+func TestTaskEventWatch(t *testing.T) {
+	watch := newTaskEventWatch(taskEventCursor{xactId: 100})
+	// the events of transaction 101 are held back by transaction 100 and sent ahead of it
+	watch.sendAhead(TaskEvent{Id: 3, XactId: 101})
+	watch.sendAhead(TaskEvent{Id: 2, XactId: 101})
+	if ids := watch.aheadIds(); !slices.Equal(ids, []int64{2, 3}) {
+		t.Errorf("ids sent ahead = %v, want [2 3]", ids)
+	}
+	if watch.cursor != (taskEventCursor{xactId: 100}) {
+		t.Errorf("cursor = %+v, want it to stay before the running transaction", watch.cursor)
+	}
+
+	// once transaction 100 commits, its event and those of transaction 101 are streamed in order
+	tests := []struct {
+		event    TaskEvent
+		wantSend bool
+	}{
+		{event: TaskEvent{Id: 4, XactId: 100}, wantSend: true},
+		{event: TaskEvent{Id: 2, XactId: 101}},
+		{event: TaskEvent{Id: 3, XactId: 101}},
+		{event: TaskEvent{Id: 5, XactId: 102}, wantSend: true},
+	}
+	for _, test := range tests {
+		if got := watch.advance(test.event); got != test.wantSend {
+			t.Errorf("advance to event %d = %t, want %t", test.event.Id, got, test.wantSend)
+		}
+		if watch.cursor != test.event.cursor() {
+			t.Errorf("cursor = %+v, want %+v", watch.cursor, test.event.cursor())
+		}
+	}
+	if ids := watch.aheadIds(); len(ids) != 0 {
+		t.Errorf("ids sent ahead = %v after the cursor passed them, want none", ids)
+	}
+}
+
+func TestTaskEventHub(t *testing.T) {
+	hub := newTaskEventHub()
+	first, unsubscribeFirst := hub.subscribe()
+	second, unsubscribeSecond := hub.subscribe()
+	defer unsubscribeSecond()
+
+	// announcements are merged while a subscriber is busy
+	hub.broadcast()
+	hub.broadcast()
+	for name, wake := range map[string]<-chan struct{}{"first": first, "second": second} {
+		select {
+		case <-wake:
+		default:
+			t.Errorf("%s subscriber was not woken up", name)
+		}
+		select {
+		case <-wake:
+			t.Errorf("%s subscriber was woken up twice", name)
+		default:
+		}
+	}
+
+	unsubscribeFirst()
+	hub.broadcast()
+	select {
+	case <-first:
+		t.Error("subscriber was woken up after it unsubscribed")
+	default:
+	}
+	select {
+	case <-second:
+	default:
+		t.Error("subscriber was not woken up")
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+	"github.com/uptrace/bun"
+)
+
+// applyTaskFilter narrows down a query over tasks according to the given filter.
+// Unset fields of the filter are ignored, and a nil filter doesn't change the query.
+func applyTaskFilter(query *bun.SelectQuery, filter *ppb.TaskFilter) (*bun.SelectQuery, error) {
+	if filter.GetPatientId() != 0 {
+		query = query.Where("? = ?", bun.Ident("task.patient_id"), filter.GetPatientId())
+	}
+	if filter.GetExpertise() != "" {
+		query = query.Where("? = ?", bun.Ident("task.expertise"), filter.GetExpertise())
+	}
+	if filter != nil && filter.Complete != nil {
+		query = query.Where("? = ?", bun.Ident("task.complete"), filter.GetComplete())
+	}
+	createdAfter, err := parseTimestamp(filter.GetCreatedAfter())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse created_after: %w", err)
+	}
+	if !createdAfter.IsZero() {
+		query = query.Where("? >= ?", bun.Ident("task.created_at"), createdAfter)
+	}
+	createdBefore, err := parseTimestamp(filter.GetCreatedBefore())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse created_before: %w", err)
+	}
+	if !createdBefore.IsZero() {
+		query = query.Where("? < ?", bun.Ident("task.created_at"), createdBefore)
+	}
+	if filter.GetAssignee() != "" {
+		query = query.Where("? = ?", bun.Ident("task.assignee"), filter.GetAssignee())
+	}
+	return query, nil
+}
+
+// applyUrgencyFilter narrows down a query over tasks by the priority and due date filters of the request.
+func applyUrgencyFilter(query *bun.SelectQuery, req *ppb.GetTasksIDsRequest) (*bun.SelectQuery, error) {
+	if req.GetOverdueOnly() {
+		query = query.
+			Where("? < current_timestamp", bun.Ident("task.due_at")).
+			Where("? NOT IN (?)", bun.Ident("task.status"), bun.In([]TaskStatus{TaskStatusDone, TaskStatusCancelled}))
+	}
+	if req.GetPriority() != ppb.Task_PRIORITY_UNSPECIFIED {
+		priority, err := taskPriorityFromGRPC(req.GetPriority())
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("? = ?", bun.Ident("task.priority"), priority)
+	}
+	dueAfter, err := parseTimestamp(req.GetDueAfter())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse due_after: %w", err)
+	}
+	if !dueAfter.IsZero() {
+		query = query.Where("? >= ?", bun.Ident("task.due_at"), dueAfter)
+	}
+	dueBefore, err := parseTimestamp(req.GetDueBefore())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse due_before: %w", err)
+	}
+	if !dueBefore.IsZero() {
+		query = query.Where("? < ?", bun.Ident("task.due_at"), dueBefore)
+	}
+	return query, nil
+}
+
+// taskSortingFor returns the ordering of a task listing by the given key and direction.
+// Search results with equal keys are ordered by relevance, and all ties are broken by the task id.
+func taskSortingFor(orderBy ppb.TaskOrderBy, descending bool, search bool) (taskSorting, error) {
+	var keys []sortKey
+	switch orderBy {
+	case ppb.TaskOrderBy_TASK_ORDER_BY_UNSPECIFIED, ppb.TaskOrderBy_TASK_ORDER_BY_ID:
+	case ppb.TaskOrderBy_TASK_ORDER_BY_DUE_AT:
+		// tasks without a due date are never urgent, so they always come last
+		expr := `coalesce("task"."due_at", 'infinity')`
+		if descending {
+			expr = `coalesce("task"."due_at", '-infinity')`
+		}
+		keys = append(keys, sortKey{expr: expr, sqlType: "timestamptz", descending: descending})
+	case ppb.TaskOrderBy_TASK_ORDER_BY_PRIORITY:
+		keys = append(keys, sortKey{expr: `"task"."priority"`, sqlType: "smallint", descending: descending})
+	case ppb.TaskOrderBy_TASK_ORDER_BY_CREATED_AT:
+		keys = append(keys, sortKey{expr: `"task"."created_at"`, sqlType: "timestamptz", descending: descending})
+	case ppb.TaskOrderBy_TASK_ORDER_BY_TITLE:
+		keys = append(keys, sortKey{expr: `coalesce("task"."title", '')`, sqlType: "varchar", descending: descending})
+	default:
+		return taskSorting{}, errors.New("unknown order_by value")
+	}
+	if search {
+		keys = append(keys, sortKey{expr: "ts_rank(text_searchable, query::tsquery)", sqlType: "real", descending: true})
+	}
+	// only an explicit order by id is allowed to be descending
+	idDescending := orderBy == ppb.TaskOrderBy_TASK_ORDER_BY_ID && descending
+	keys = append(keys, sortKey{expr: `"task"."id"`, sqlType: "integer", descending: idDescending})
+
+	return taskSorting{
+		keys:      keys,
+		signature: fmt.Sprintf("%d/%t/%t", orderBy, descending, search),
+	}, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"testing"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+)
+
+func TestTaskSortingFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		orderBy    ppb.TaskOrderBy
+		descending bool
+		search     bool
+		want       []sortKey
+		wantErr    bool
+	}{
+		{
+			name:    "default",
+			orderBy: ppb.TaskOrderBy_TASK_ORDER_BY_UNSPECIFIED,
+			want:    []sortKey{{expr: `"task"."id"`, sqlType: "integer"}},
+		},
+		{
+			name:       "id descending",
+			orderBy:    ppb.TaskOrderBy_TASK_ORDER_BY_ID,
+			descending: true,
+			want:       []sortKey{{expr: `"task"."id"`, sqlType: "integer", descending: true}},
+		},
+		{
+			name:       "unspecified is never descending",
+			orderBy:    ppb.TaskOrderBy_TASK_ORDER_BY_UNSPECIFIED,
+			descending: true,
+			want:       []sortKey{{expr: `"task"."id"`, sqlType: "integer"}},
+		},
+		{
+			name:    "due date puts tasks without one last",
+			orderBy: ppb.TaskOrderBy_TASK_ORDER_BY_DUE_AT,
+			want: []sortKey{
+				{expr: `coalesce("task"."due_at", 'infinity')`, sqlType: "timestamptz"},
+				{expr: `"task"."id"`, sqlType: "integer"},
+			},
+		},
+		{
+			name:       "due date descending puts tasks without one last",
+			orderBy:    ppb.TaskOrderBy_TASK_ORDER_BY_DUE_AT,
+			descending: true,
+			want: []sortKey{
+				{expr: `coalesce("task"."due_at", '-infinity')`, sqlType: "timestamptz", descending: true},
+				{expr: `"task"."id"`, sqlType: "integer"},
+			},
+		},
+		{
+			name:       "priority",
+			orderBy:    ppb.TaskOrderBy_TASK_ORDER_BY_PRIORITY,
+			descending: true,
+			want: []sortKey{
+				{expr: `"task"."priority"`, sqlType: "smallint", descending: true},
+				{expr: `"task"."id"`, sqlType: "integer"},
+			},
+		},
+		{
+			name:    "title with search",
+			orderBy: ppb.TaskOrderBy_TASK_ORDER_BY_TITLE,
+			search:  true,
+			want: []sortKey{
+				{expr: `coalesce("task"."title", '')`, sqlType: "varchar"},
+				{expr: "ts_rank(text_searchable, query::tsquery)", sqlType: "real", descending: true},
+				{expr: `"task"."id"`, sqlType: "integer"},
+			},
+		},
+		{name: "unknown", orderBy: ppb.TaskOrderBy(100), wantErr: true},
+	}
+	for _, test := range tests {
+		sorting, err := taskSortingFor(test.orderBy, test.descending, test.search)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: taskSortingFor = %+v, want an error", test.name, sorting)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: taskSortingFor returned an error: %v", test.name, err)
+			continue
+		}
+		if len(sorting.keys) != len(test.want) {
+			t.Errorf("%s: keys = %+v, want %+v", test.name, sorting.keys, test.want)
+			continue
+		}
+		for i := range test.want {
+			if sorting.keys[i] != test.want[i] {
+				t.Errorf("%s: key %d = %+v, want %+v", test.name, i, sorting.keys[i], test.want[i])
+			}
+		}
+	}
+}
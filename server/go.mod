@@ -13,6 +13,7 @@ require (
 	github.com/uptrace/bun/driver/pgdriver v1.2.1
 	go.uber.org/zap v1.27.0
 	google.golang.org/grpc v1.65.0
+	google.golang.org/protobuf v1.34.2
 )
 
 require (
@@ -36,7 +37,6 @@ require (
 	golang.org/x/sys v0.24.0 // indirect
 	golang.org/x/text v0.17.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240812133136-8ffd90a71988 // indirect
-	google.golang.org/protobuf v1.34.2 // indirect
 	k8s.io/apimachinery v0.31.0 // indirect
 	mellium.im/sasl v0.3.1 // indirect
 )
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var errMissingToken = errors.New("token is missing, send it as authorization metadata")
+
+// authorizationMetadataKey is the metadata key that carries the token of the caller as "Bearer <token>".
+const authorizationMetadataKey = "authorization"
+
+// methodPermission returns the permission that a caller of the RPC has to be granted.
+// An empty permission means that any authenticated caller may call the RPC, since the handler
+// applies per-task rules on its own. RPCs that are not listed are denied, so new RPCs can't be left unprotected.
+func methodPermission(fullMethod string) (permission, error) {
+	switch fullMethod {
+	case ppb.TasksService_GetTasks_FullMethodName,
+		ppb.TasksService_GetTasksIDs_FullMethodName,
+		ppb.TasksService_GetTasksByPatient_FullMethodName,
+		ppb.TasksService_GetTaskHistory_FullMethodName,
+		ppb.TasksService_GetTaskTemplate_FullMethodName,
+		ppb.TasksService_GetTaskTemplates_FullMethodName,
+		ppb.TasksService_WatchTasks_FullMethodName:
+		return permissionRead, nil
+	case ppb.TasksService_CreateTask_FullMethodName,
+		ppb.TasksService_UpdateTask_FullMethodName,
+		ppb.TasksService_AddChecklistItem_FullMethodName,
+		ppb.TasksService_DeleteChecklistItem_FullMethodName,
+		ppb.TasksService_AddDependency_FullMethodName,
+		ppb.TasksService_RemoveDependency_FullMethodName,
+		ppb.TasksService_CreateTaskTemplate_FullMethodName,
+		ppb.TasksService_DeleteTaskTemplate_FullMethodName:
+		return permissionWrite, nil
+	case ppb.TasksService_DeleteTask_FullMethodName,
+		ppb.TasksService_RestoreTask_FullMethodName,
+		ppb.TasksService_PurgeTask_FullMethodName:
+		return permissionDelete, nil
+	case ppb.TasksService_CreateWebhook_FullMethodName,
+		ppb.TasksService_GetWebhooks_FullMethodName,
+		ppb.TasksService_DeleteWebhook_FullMethodName,
+		ppb.TasksService_ListWebhookDeliveries_FullMethodName:
+		return permissionWebhooks, nil
+	case ppb.TasksService_ArchivePatientTasks_FullMethodName,
+		ppb.TasksService_RestorePatientTasks_FullMethodName:
+		return permissionPatients, nil
+	case ppb.TasksService_GetTask_FullMethodName,
+		ppb.TasksService_AssignTask_FullMethodName,
+		ppb.TasksService_UnassignTask_FullMethodName,
+		ppb.TasksService_GetMyTasksIDs_FullMethodName,
+		ppb.TasksService_TransitionTask_FullMethodName,
+		ppb.TasksService_UpdateChecklistItem_FullMethodName,
+		ppb.TasksService_AddComment_FullMethodName,
+		ppb.TasksService_ListComments_FullMethodName,
+		ppb.TasksService_EditComment_FullMethodName,
+		ppb.TasksService_DeleteComment_FullMethodName,
+		ppb.TasksService_GetNotificationPreferences_FullMethodName,
+		ppb.TasksService_UpdateNotificationPreferences_FullMethodName,
+		ppb.TasksService_GetMyNotifications_FullMethodName,
+		ppb.TasksService_MarkNotificationsRead_FullMethodName:
+		return "", nil
+	default:
+		return "", fmt.Errorf("method %s is not covered by the permission policy", fullMethod)
+	}
+}
+
+// requestToken returns the token of the caller from the authorization metadata.
+// Clients that don't send the metadata may send the token in the token field of the request instead.
+func requestToken(ctx context.Context, req interface{}) (string, error) {
+	if values := metadata.ValueFromIncomingContext(ctx, authorizationMetadataKey); len(values) > 0 {
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return "", errors.New("authorization metadata has to be in the form of Bearer <token>")
+		}
+		return token, nil
+	}
+	if withToken, ok := req.(interface{ GetToken() string }); ok && withToken.GetToken() != "" {
+		return withToken.GetToken(), nil
+	}
+	return "", errMissingToken
+}
+
+// authenticate verifies the token of the caller and checks that it is granted the permission required by the RPC.
+// The verified caller is stored in the returned context.
+// If the token is missing or not valid, codes.Unauthenticated is returned.
+// If the permission is not granted, codes.PermissionDenied is returned.
+func (server tasksServer) authenticate(ctx context.Context, fullMethod string, req interface{}) (
+	context.Context, error) {
+	token, err := requestToken(ctx, req)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	claims, err := server.VerifyToken(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	subject, err := claimsSubject(claims, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	perm, err := methodPermission(fullMethod)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if perm != "" && !server.permissions.allows(claims, perm) {
+		return nil, status.Error(codes.PermissionDenied, permissionDeniedMessage)
+	}
+	return withCaller(ctx, caller{claims: claims, subject: subject, token: token}), nil
+}
+
+// unaryAuthInterceptor authenticates and authorizes every unary RPC before its handler is called.
+func (server tasksServer) unaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := server.authenticate(ctx, info.FullMethod, req)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamAuthInterceptor authenticates and authorizes every streaming RPC.
+// If the caller sends the authorization metadata, the stream is authenticated before its handler is called.
+// Otherwise, the token is taken from the first message the handler receives.
+func (server tasksServer) streamAuthInterceptor(srv interface{}, stream grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	wrapped := &authServerStream{ServerStream: stream, server: server, fullMethod: info.FullMethod}
+	if len(metadata.ValueFromIncomingContext(stream.Context(), authorizationMetadataKey)) > 0 {
+		ctx, err := server.authenticate(stream.Context(), info.FullMethod, nil)
+		if err != nil {
+			return err
+		}
+		wrapped.ctx = ctx
+	}
+	return handler(srv, wrapped)
+}
+
+// authServerStream is a server stream that authenticates the caller by the first received message,
+// unless it was already authenticated by the metadata.
+type authServerStream struct {
+	grpc.ServerStream
+	server     tasksServer
+	fullMethod string
+	// ctx is the context with the verified caller, nil until the caller is authenticated
+	ctx context.Context
+}
+
+// Context returns the context of the stream, with the verified caller once it is authenticated.
+func (stream *authServerStream) Context() context.Context {
+	if stream.ctx != nil {
+		return stream.ctx
+	}
+	return stream.ServerStream.Context()
+}
+
+// RecvMsg receives a message and authenticates the caller by it, if the caller wasn't authenticated yet.
+func (stream *authServerStream) RecvMsg(m interface{}) error {
+	if err := stream.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if stream.ctx != nil {
+		return nil
+	}
+	ctx, err := stream.server.authenticate(stream.ServerStream.Context(), stream.fullMethod, m)
+	if err != nil {
+		return err
+	}
+	stream.ctx = ctx
+	return nil
+}
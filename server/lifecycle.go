@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+	"github.com/uptrace/bun"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TaskStatus is a state in the lifecycle of a task.
+type TaskStatus string
+
+// Statuses a task can be in, as stored in the database.
+const (
+	TaskStatusOpen       TaskStatus = "open"
+	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusBlocked    TaskStatus = "blocked"
+	TaskStatusDone       TaskStatus = "done"
+	TaskStatusCancelled  TaskStatus = "cancelled"
+)
+
+// toGRPC returns a GRPC version of TaskStatus.
+func (taskStatus TaskStatus) toGRPC() ppb.Task_Status {
+	switch taskStatus {
+	case TaskStatusOpen:
+		return ppb.Task_STATUS_OPEN
+	case TaskStatusInProgress:
+		return ppb.Task_STATUS_IN_PROGRESS
+	case TaskStatusBlocked:
+		return ppb.Task_STATUS_BLOCKED
+	case TaskStatusDone:
+		return ppb.Task_STATUS_DONE
+	case TaskStatusCancelled:
+		return ppb.Task_STATUS_CANCELLED
+	default:
+		return ppb.Task_STATUS_UNSPECIFIED
+	}
+}
+
+// taskStatusFromGRPC returns a TaskStatus from a GRPC version.
+func taskStatusFromGRPC(taskStatus ppb.Task_Status) (TaskStatus, error) {
+	switch taskStatus {
+	case ppb.Task_STATUS_OPEN:
+		return TaskStatusOpen, nil
+	case ppb.Task_STATUS_IN_PROGRESS:
+		return TaskStatusInProgress, nil
+	case ppb.Task_STATUS_BLOCKED:
+		return TaskStatusBlocked, nil
+	case ppb.Task_STATUS_DONE:
+		return TaskStatusDone, nil
+	case ppb.Task_STATUS_CANCELLED:
+		return TaskStatusCancelled, nil
+	case ppb.Task_STATUS_UNSPECIFIED:
+		return "", errors.New("task status is required")
+	default:
+		return "", fmt.Errorf("unknown task status %d", taskStatus)
+	}
+}
+
+// canTransition reports whether a task is allowed to move from one status to another.
+// Finished tasks (done or cancelled) can only be reopened.
+func canTransition(from, to TaskStatus) bool {
+	switch from {
+	case TaskStatusOpen:
+		return to == TaskStatusInProgress || to == TaskStatusBlocked || to == TaskStatusDone || to == TaskStatusCancelled
+	case TaskStatusInProgress:
+		return to == TaskStatusOpen || to == TaskStatusBlocked || to == TaskStatusDone || to == TaskStatusCancelled
+	case TaskStatusBlocked:
+		return to == TaskStatusOpen || to == TaskStatusInProgress || to == TaskStatusCancelled
+	case TaskStatusDone, TaskStatusCancelled:
+		return to == TaskStatusOpen
+	default:
+		return false
+	}
+}
+
+// transitionTask moves the task to the given status on behalf of actor, records the transition
+// and appends the change to the task history under the given audit action.
+// The task has to be locked by tx. Illegal transitions are rejected with codes.FailedPrecondition,
+// as well as starting or completing a task while some of its prerequisites are not finished,
+// and reopening a task of an archived patient.
+func transitionTask(ctx context.Context, tx bun.Tx, task *Task, to TaskStatus, actor string, action string) error {
+	if !canTransition(task.Status, to) {
+		return status.Error(codes.FailedPrecondition,
+			fmt.Sprintf("task can't move from %s to %s", task.Status, to))
+	}
+	if to == TaskStatusOpen {
+		if err := checkPatientNotArchived(ctx, tx, task.PatientId); err != nil {
+			return err
+		}
+	}
+	return moveTask(ctx, tx, task, to, actor, action)
+}
+
+// moveTask is transitionTask without the checks that the transition is allowed and that the patient isn't archived,
+// for the moves the service makes on its own, like restoring an archived task to its previous status.
+// Starting or completing a task while some of its prerequisites are not finished is still rejected
+// with codes.FailedPrecondition, before anything is written.
+func moveTask(ctx context.Context, tx bun.Tx, task *Task, to TaskStatus, actor string, action string) error {
+	if to == TaskStatusInProgress || to == TaskStatusDone {
+		if err := checkNotBlocked(ctx, tx, task); err != nil {
+			return err
+		}
+	}
+
+	before := *task
+	transition := TaskTransition{
+		TaskId:     task.Id,
+		FromStatus: task.Status,
+		ToStatus:   to,
+		Actor:      actor,
+	}
+	if _, err := tx.NewInsert().Model(&transition).Exec(ctx); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to record a task transition: %w", err).Error())
+	}
+
+	task.Status = to
+	task.Complete = to == TaskStatusDone
+	task.StatusChangedAt = time.Now()
+	task.StatusChangedBy = actor
+	task.Version++
+	if _, err := tx.NewUpdate().
+		Model(task).
+		Column("status", "complete", "status_changed_at", "status_changed_by", "version").
+		WherePK().
+		Exec(ctx); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to update a task status: %w", err).Error())
+	}
+	eventType := TaskEventUpdated
+	if to == TaskStatusDone {
+		eventType = TaskEventCompleted
+	}
+	if err := publishTaskEvent(ctx, tx, task.Id, eventType); err != nil {
+		return err
+	}
+	return recordTaskAudit(ctx, tx, action, actor, &before, task)
+}
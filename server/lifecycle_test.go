@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	statuses := []TaskStatus{TaskStatusOpen, TaskStatusInProgress, TaskStatusBlocked, TaskStatusDone, TaskStatusCancelled}
+	allowed := map[TaskStatus][]TaskStatus{
+		TaskStatusOpen:       {TaskStatusInProgress, TaskStatusBlocked, TaskStatusDone, TaskStatusCancelled},
+		TaskStatusInProgress: {TaskStatusOpen, TaskStatusBlocked, TaskStatusDone, TaskStatusCancelled},
+		TaskStatusBlocked:    {TaskStatusOpen, TaskStatusInProgress, TaskStatusCancelled},
+		TaskStatusDone:       {TaskStatusOpen},
+		TaskStatusCancelled:  {TaskStatusOpen},
+	}
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := false
+			for _, status := range allowed[from] {
+				want = want || status == to
+			}
+			if got := canTransition(from, to); got != want {
+				t.Errorf("canTransition(%s, %s) = %t, want %t", from, to, got, want)
+			}
+		}
+	}
+	if canTransition("", TaskStatusOpen) || canTransition(TaskStatusOpen, "unknown") {
+		t.Error("transitions from or to an unknown status are allowed")
+	}
+}
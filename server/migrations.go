@@ -0,0 +1,562 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+// migrationsLockID is the key of the Postgres advisory lock held while migrations run,
+// so replicas starting at the same time don't apply the same migration twice.
+const migrationsLockID = 7_326_448_173
+
+// migrateDownAll is the target of `migrate down` that rolls back every migration, dropping the tasks tables.
+const migrateDownAll = "--all"
+
+var (
+	errUnknownMigrateCommand = errors.New("unknown migrate command, expected one of: status, up, down")
+	errMissingMigrateTarget  = errors.New("migrate down requires a target: the name of the migration " +
+		"to roll back to, or " + migrateDownAll + " to roll back every migration and drop all the tables")
+)
+
+// sqlMigration returns a migration function that executes the given statements in a single transaction.
+func sqlMigration(statements ...string) migrate.MigrationFunc {
+	return func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			for _, statement := range statements {
+				if _, err := tx.ExecContext(ctx, statement); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// schemaMigrations returns all schema migrations of task microservice.
+// Migrations are applied in the order of their names, so new migrations must be appended with a greater name.
+// Never edit a migration that was already released, add a new one instead.
+func schemaMigrations() *migrate.Migrations {
+	migrations := migrate.NewMigrations()
+	migrations.Add(migrate.Migration{
+		Name:    "20240801000000",
+		Comment: "create_tasks",
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS tasks (" +
+				"id serial NOT NULL, " +
+				"complete boolean, " +
+				"title varchar, " +
+				"description varchar, " +
+				"expertise varchar, " +
+				"patient_id integer, " +
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, " +
+				"deleted_at timestamptz, " +
+				"PRIMARY KEY (id))"),
+		Down: sqlMigration("DROP TABLE IF EXISTS tasks"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20240815000000",
+		Comment: "add_tasks_special_note",
+		Up:      sqlMigration("ALTER TABLE tasks ADD COLUMN IF NOT EXISTS special_note varchar"),
+		Down:    sqlMigration("ALTER TABLE tasks DROP COLUMN IF EXISTS special_note"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20240901000000",
+		Comment: "add_tasks_text_search",
+		// Postgres specific code. Add a text_searchable column for full-text search.
+		Up: sqlMigration(
+			"ALTER TABLE tasks "+
+				"ADD COLUMN IF NOT EXISTS text_searchable tsvector "+
+				"GENERATED ALWAYS AS "+
+				"("+
+				"setweight(to_tsvector('simple', coalesce(title, '')), 'A')       || "+
+				"setweight(to_tsvector('simple', coalesce(expertise, '')), 'B')   || "+
+				"setweight(to_tsvector('simple', coalesce(description, '')), 'C') || "+
+				"setweight(to_tsvector('simple', coalesce(special_note, '')), 'D')"+
+				") STORED",
+			"CREATE INDEX IF NOT EXISTS tasks_text_searchable_idx ON tasks USING GIN (text_searchable)"),
+		Down: sqlMigration(
+			"DROP INDEX IF EXISTS tasks_text_searchable_idx",
+			"ALTER TABLE tasks DROP COLUMN IF EXISTS text_searchable"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241001000000",
+		Comment: "add_tasks_assignee",
+		Up: sqlMigration(
+			"ALTER TABLE tasks "+
+				"ADD COLUMN IF NOT EXISTS assignee varchar, "+
+				"ADD COLUMN IF NOT EXISTS assigned_at timestamptz",
+			"CREATE INDEX IF NOT EXISTS tasks_assignee_idx ON tasks (assignee)"),
+		Down: sqlMigration(
+			"DROP INDEX IF EXISTS tasks_assignee_idx",
+			"ALTER TABLE tasks DROP COLUMN IF EXISTS assignee, DROP COLUMN IF EXISTS assigned_at"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241008000000",
+		Comment: "add_tasks_status",
+		Up: sqlMigration(
+			"ALTER TABLE tasks "+
+				"ADD COLUMN IF NOT EXISTS status varchar NOT NULL DEFAULT 'open', "+
+				"ADD COLUMN IF NOT EXISTS status_changed_at timestamptz, "+
+				"ADD COLUMN IF NOT EXISTS status_changed_by varchar",
+			"UPDATE tasks SET status = 'done' WHERE complete",
+			"CREATE TABLE IF NOT EXISTS task_transitions ("+
+				"id bigserial NOT NULL, "+
+				"task_id integer NOT NULL REFERENCES tasks (id) ON DELETE CASCADE, "+
+				"from_status varchar NOT NULL, "+
+				"to_status varchar NOT NULL, "+
+				"actor varchar NOT NULL, "+
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (id))",
+			"CREATE INDEX IF NOT EXISTS task_transitions_task_id_idx ON task_transitions (task_id)"),
+		Down: sqlMigration(
+			"DROP TABLE IF EXISTS task_transitions",
+			"ALTER TABLE tasks "+
+				"DROP COLUMN IF EXISTS status, "+
+				"DROP COLUMN IF EXISTS status_changed_at, "+
+				"DROP COLUMN IF EXISTS status_changed_by"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241015000000",
+		Comment: "add_tasks_due_at_and_priority",
+		Up: sqlMigration(
+			"ALTER TABLE tasks "+
+				"ADD COLUMN IF NOT EXISTS due_at timestamptz, "+
+				"ADD COLUMN IF NOT EXISTS priority smallint NOT NULL DEFAULT 2",
+			"CREATE INDEX IF NOT EXISTS tasks_due_at_idx ON tasks (due_at)",
+			"CREATE INDEX IF NOT EXISTS tasks_priority_idx ON tasks (priority)"),
+		Down: sqlMigration(
+			"DROP INDEX IF EXISTS tasks_due_at_idx",
+			"DROP INDEX IF EXISTS tasks_priority_idx",
+			"ALTER TABLE tasks DROP COLUMN IF EXISTS due_at, DROP COLUMN IF EXISTS priority"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241022000000",
+		Comment: "add_tasks_version",
+		Up:      sqlMigration("ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1"),
+		Down:    sqlMigration("ALTER TABLE tasks DROP COLUMN IF EXISTS version"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241029000000",
+		Comment: "create_task_audit_log",
+		// The audit log is append-only, updates and deletes of its rows are silently ignored.
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS task_audit_log ("+
+				"id bigserial NOT NULL, "+
+				"task_id integer NOT NULL, "+
+				"action varchar NOT NULL, "+
+				"actor varchar NOT NULL, "+
+				"changes jsonb NOT NULL, "+
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (id))",
+			"CREATE INDEX IF NOT EXISTS task_audit_log_task_id_idx ON task_audit_log (task_id, id)",
+			"CREATE OR REPLACE RULE task_audit_log_no_update AS ON UPDATE TO task_audit_log DO INSTEAD NOTHING",
+			"CREATE OR REPLACE RULE task_audit_log_no_delete AS ON DELETE TO task_audit_log DO INSTEAD NOTHING"),
+		Down: sqlMigration("DROP TABLE IF EXISTS task_audit_log"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241105000000",
+		Comment: "create_task_checklist_items",
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS task_checklist_items ("+
+				"id serial NOT NULL, "+
+				"task_id integer NOT NULL REFERENCES tasks (id) ON DELETE CASCADE, "+
+				"title varchar NOT NULL, "+
+				"done boolean NOT NULL DEFAULT false, "+
+				"position integer NOT NULL, "+
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (id))",
+			"CREATE INDEX IF NOT EXISTS task_checklist_items_task_id_idx ON task_checklist_items (task_id, position)"),
+		Down: sqlMigration("DROP TABLE IF EXISTS task_checklist_items"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241112000000",
+		Comment: "create_task_dependencies",
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS task_dependencies ("+
+				"task_id integer NOT NULL REFERENCES tasks (id) ON DELETE CASCADE, "+
+				"depends_on_id integer NOT NULL REFERENCES tasks (id) ON DELETE CASCADE, "+
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (task_id, depends_on_id), "+
+				"CHECK (task_id <> depends_on_id))",
+			"CREATE INDEX IF NOT EXISTS task_dependencies_depends_on_id_idx ON task_dependencies (depends_on_id)"),
+		Down: sqlMigration("DROP TABLE IF EXISTS task_dependencies"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241119000000",
+		Comment: "create_task_templates",
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS task_templates ("+
+				"id serial NOT NULL, "+
+				"title varchar, "+
+				"description varchar, "+
+				"expertise varchar, "+
+				"patient_id integer, "+
+				"special_note varchar, "+
+				"priority smallint NOT NULL, "+
+				"recurrence varchar NOT NULL, "+
+				"starts_at timestamptz NOT NULL, "+
+				"due_in_hours integer, "+
+				"next_run_at timestamptz NOT NULL, "+
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (id))",
+			"CREATE INDEX IF NOT EXISTS task_templates_next_run_at_idx ON task_templates (next_run_at)",
+			"ALTER TABLE tasks "+
+				"ADD COLUMN IF NOT EXISTS template_id integer REFERENCES task_templates (id) ON DELETE SET NULL, "+
+				"ADD COLUMN IF NOT EXISTS occurrence_at timestamptz",
+			// an occurrence of a template never gets two tasks, even if two schedulers race
+			"CREATE UNIQUE INDEX IF NOT EXISTS tasks_template_id_occurrence_at_idx ON tasks (template_id, occurrence_at)"),
+		Down: sqlMigration(
+			"DROP INDEX IF EXISTS tasks_template_id_occurrence_at_idx",
+			"ALTER TABLE tasks DROP COLUMN IF EXISTS template_id, DROP COLUMN IF EXISTS occurrence_at",
+			"DROP TABLE IF EXISTS task_templates"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241126000000",
+		Comment: "create_task_comments",
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS task_comments ("+
+				"id serial NOT NULL, "+
+				"task_id integer NOT NULL REFERENCES tasks (id) ON DELETE CASCADE, "+
+				"author varchar NOT NULL, "+
+				"body varchar NOT NULL, "+
+				"edited_at timestamptz, "+
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"deleted_at timestamptz, "+
+				"PRIMARY KEY (id))",
+			"CREATE INDEX IF NOT EXISTS task_comments_task_id_idx ON task_comments (task_id, id)"),
+		Down: sqlMigration("DROP TABLE IF EXISTS task_comments"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241203000000",
+		Comment: "create_task_events",
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS task_events (" +
+				"id bigserial NOT NULL, " +
+				"task_id integer NOT NULL, " +
+				"type varchar NOT NULL, " +
+				"patient_id integer, " +
+				"assignee varchar, " +
+				"expertise varchar, " +
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, " +
+				"PRIMARY KEY (id))"),
+		Down: sqlMigration("DROP TABLE IF EXISTS task_events"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241210000000",
+		Comment: "create_task_outbox",
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS task_outbox ("+
+				"id bigserial NOT NULL, "+
+				"task_id integer NOT NULL, "+
+				"type varchar NOT NULL, "+
+				"payload jsonb NOT NULL, "+
+				"attempts integer NOT NULL DEFAULT 0, "+
+				"last_error varchar, "+
+				"next_attempt_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"published_at timestamptz, "+
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (id))",
+			"CREATE INDEX IF NOT EXISTS task_outbox_pending_idx ON task_outbox (id) WHERE published_at IS NULL"),
+		Down: sqlMigration("DROP TABLE IF EXISTS task_outbox"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241217000000",
+		Comment: "create_task_webhooks",
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS task_webhooks ("+
+				"id serial NOT NULL, "+
+				"url varchar NOT NULL, "+
+				"event_types varchar[], "+
+				"secret varchar NOT NULL, "+
+				"created_by varchar NOT NULL, "+
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (id))",
+			"CREATE TABLE IF NOT EXISTS task_webhook_deliveries ("+
+				"id bigserial NOT NULL, "+
+				"webhook_id integer NOT NULL REFERENCES task_webhooks (id) ON DELETE CASCADE, "+
+				"event_id bigint NOT NULL, "+
+				"event_type varchar NOT NULL, "+
+				"task_id integer NOT NULL, "+
+				"payload jsonb NOT NULL, "+
+				"state varchar NOT NULL DEFAULT 'pending', "+
+				"attempts integer NOT NULL DEFAULT 0, "+
+				"last_error varchar, "+
+				"last_status_code integer, "+
+				"next_attempt_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"delivered_at timestamptz, "+
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (id))",
+			"CREATE INDEX IF NOT EXISTS task_webhook_deliveries_webhook_id_idx "+
+				"ON task_webhook_deliveries (webhook_id, state, id)",
+			"CREATE INDEX IF NOT EXISTS task_webhook_deliveries_pending_idx "+
+				"ON task_webhook_deliveries (id) WHERE state = 'pending'"),
+		Down: sqlMigration(
+			"DROP TABLE IF EXISTS task_webhook_deliveries",
+			"DROP TABLE IF EXISTS task_webhooks"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241224000000",
+		Comment: "create_task_reminders",
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS task_notification_preferences ("+
+				"subject varchar NOT NULL, "+
+				"channels varchar[], "+
+				"email varchar, "+
+				"phone varchar, "+
+				"remind_before_minutes integer NOT NULL DEFAULT 0, "+
+				"updated_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (subject))",
+			"CREATE TABLE IF NOT EXISTS task_reminders ("+
+				"id bigserial NOT NULL, "+
+				"task_id integer NOT NULL REFERENCES tasks (id) ON DELETE CASCADE, "+
+				"recipient varchar NOT NULL, "+
+				"due_at timestamptz NOT NULL, "+
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (id), "+
+				"UNIQUE (task_id, recipient, due_at))",
+			"CREATE TABLE IF NOT EXISTS task_notifications ("+
+				"id bigserial NOT NULL, "+
+				"recipient varchar NOT NULL, "+
+				"task_id integer NOT NULL REFERENCES tasks (id) ON DELETE CASCADE, "+
+				"message varchar NOT NULL, "+
+				"read_at timestamptz, "+
+				"created_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (id))",
+			"CREATE INDEX IF NOT EXISTS task_notifications_recipient_idx ON task_notifications (recipient, id)"),
+		Down: sqlMigration(
+			"DROP TABLE IF EXISTS task_notifications",
+			"DROP TABLE IF EXISTS task_reminders",
+			"DROP TABLE IF EXISTS task_notification_preferences"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20241231000000",
+		Comment: "create_task_archives",
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS task_archives ("+
+				"task_id integer NOT NULL REFERENCES tasks (id) ON DELETE CASCADE, "+
+				"patient_id integer NOT NULL, "+
+				"previous_status varchar NOT NULL, "+
+				"reason varchar NOT NULL, "+
+				"archived_by varchar NOT NULL, "+
+				"archived_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (task_id))",
+			"CREATE INDEX IF NOT EXISTS task_archives_patient_id_idx ON task_archives (patient_id)"),
+		Down: sqlMigration("DROP TABLE IF EXISTS task_archives"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20250107000000",
+		Comment: "add_task_events_xact_id",
+		Up: sqlMigration(
+			"ALTER TABLE task_events ADD COLUMN IF NOT EXISTS xact_id xid8 NOT NULL DEFAULT pg_current_xact_id()",
+			"CREATE INDEX IF NOT EXISTS task_events_xact_id_idx ON task_events (xact_id, id)",
+			"CREATE INDEX IF NOT EXISTS task_events_created_at_idx ON task_events (created_at)"),
+		Down: sqlMigration(
+			"DROP INDEX IF EXISTS task_events_created_at_idx",
+			"DROP INDEX IF EXISTS task_events_xact_id_idx",
+			"ALTER TABLE task_events DROP COLUMN IF EXISTS xact_id"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20250114000000",
+		Comment: "add_task_outbox_dead_at",
+		Up: sqlMigration(
+			"ALTER TABLE task_outbox ADD COLUMN IF NOT EXISTS dead_at timestamptz",
+			"DROP INDEX IF EXISTS task_outbox_pending_idx",
+			"CREATE INDEX IF NOT EXISTS task_outbox_pending_idx ON task_outbox (next_attempt_at, id) "+
+				"WHERE published_at IS NULL AND dead_at IS NULL"),
+		Down: sqlMigration(
+			"DROP INDEX IF EXISTS task_outbox_pending_idx",
+			"CREATE INDEX IF NOT EXISTS task_outbox_pending_idx ON task_outbox (id) WHERE published_at IS NULL",
+			"ALTER TABLE task_outbox DROP COLUMN IF EXISTS dead_at"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20250121000000",
+		Comment: "add_task_webhooks_encrypted_secret",
+		// the plaintext secrets are encrypted and removed on startup, see sealWebhookSecrets
+		Up: sqlMigration(
+			"ALTER TABLE task_webhooks ADD COLUMN IF NOT EXISTS encrypted_secret bytea",
+			"ALTER TABLE task_webhooks ALTER COLUMN secret DROP NOT NULL"),
+		// encrypted secrets can't be restored without the key, so webhooks that have only those are removed
+		Down: sqlMigration(
+			"DELETE FROM task_webhooks WHERE secret IS NULL",
+			"ALTER TABLE task_webhooks ALTER COLUMN secret SET NOT NULL",
+			"ALTER TABLE task_webhooks DROP COLUMN IF EXISTS encrypted_secret"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20250128000000",
+		Comment: "add_task_reminders_attempts",
+		// the existing reminders were sent, failed ones were removed to be retried
+		Up: sqlMigration(
+			"ALTER TABLE task_reminders ADD COLUMN IF NOT EXISTS attempts integer NOT NULL DEFAULT 0, "+
+				"ADD COLUMN IF NOT EXISTS last_error varchar, "+
+				"ADD COLUMN IF NOT EXISTS next_attempt_at timestamptz, "+
+				"ADD COLUMN IF NOT EXISTS sent_at timestamptz",
+			"UPDATE task_reminders SET attempts = 1, sent_at = created_at WHERE sent_at IS NULL",
+			"ALTER TABLE task_reminders ALTER COLUMN next_attempt_at SET DEFAULT current_timestamp"),
+		// reminders that weren't sent are removed, so that they are retried as before
+		Down: sqlMigration(
+			"DELETE FROM task_reminders WHERE sent_at IS NULL",
+			"ALTER TABLE task_reminders DROP COLUMN IF EXISTS attempts, "+
+				"DROP COLUMN IF EXISTS last_error, "+
+				"DROP COLUMN IF EXISTS next_attempt_at, "+
+				"DROP COLUMN IF EXISTS sent_at"),
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "20250204000000",
+		Comment: "create_patient_archives",
+		Up: sqlMigration(
+			"CREATE TABLE IF NOT EXISTS patient_archives ("+
+				"patient_id integer NOT NULL, "+
+				"reason varchar NOT NULL, "+
+				"archived_by varchar NOT NULL, "+
+				"archived_at timestamptz NOT NULL DEFAULT current_timestamp, "+
+				"PRIMARY KEY (patient_id))",
+			// patients whose tasks are archived are archived themselves
+			"INSERT INTO patient_archives (patient_id, reason, archived_by, archived_at) "+
+				"SELECT DISTINCT ON (patient_id) patient_id, reason, archived_by, archived_at FROM task_archives "+
+				"ORDER BY patient_id, archived_at DESC "+
+				"ON CONFLICT (patient_id) DO NOTHING",
+			"ALTER TABLE task_archives ADD COLUMN IF NOT EXISTS archived_version bigint"),
+		Down: sqlMigration(
+			"ALTER TABLE task_archives DROP COLUMN IF EXISTS archived_version",
+			"DROP TABLE IF EXISTS patient_archives"),
+	})
+	return migrations
+}
+
+// withMigrationsLock runs fn while holding the migrations advisory lock.
+// The lock is session scoped, so it is taken on a dedicated connection that is kept until fn returns.
+func withMigrationsLock(ctx context.Context, db *bun.DB, fn func(migrator *migrate.Migrator) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire a connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock(?)", migrationsLockID); err != nil {
+		return fmt.Errorf("failed to acquire migrations lock: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock(?)", migrationsLockID)
+	}()
+
+	migrator := migrate.NewMigrator(db, schemaMigrations(), migrate.WithMarkAppliedOnSuccess(true))
+	if err = migrator.Init(ctx); err != nil {
+		return fmt.Errorf("failed to create migrations tables: %w", err)
+	}
+	return fn(migrator)
+}
+
+// migrateUp applies all migrations that weren't applied yet.
+func migrateUp(ctx context.Context, db *bun.DB) (*migrate.MigrationGroup, error) {
+	var group *migrate.MigrationGroup
+	err := withMigrationsLock(ctx, db, func(migrator *migrate.Migrator) error {
+		var err error
+		group, err = migrator.Migrate(ctx)
+		return err
+	})
+	return group, err
+}
+
+// migrateDown rolls back, newest first, every applied migration with a name greater than target,
+// so target stays applied. If target is migrateDownAll, every migration is rolled back.
+// The rolled back migrations are returned.
+func migrateDown(ctx context.Context, db *bun.DB, target string) (migrate.MigrationSlice, error) {
+	var rolledBack migrate.MigrationSlice
+	err := withMigrationsLock(ctx, db, func(migrator *migrate.Migrator) error {
+		migrations, err := migrator.MigrationsWithStatus(ctx)
+		if err != nil {
+			return err
+		}
+		if target != migrateDownAll && !slices.ContainsFunc(migrations.Applied(), func(migration migrate.Migration) bool {
+			return migration.Name == target
+		}) {
+			return fmt.Errorf("migration %q is not applied", target)
+		}
+		for i := len(migrations) - 1; i >= 0; i-- {
+			migration := &migrations[i]
+			if !migration.IsApplied() || (target != migrateDownAll && migration.Name <= target) {
+				continue
+			}
+			if migration.Down != nil {
+				if err = migration.Down(ctx, db); err != nil {
+					return fmt.Errorf("failed to roll back %s: %w", migration, err)
+				}
+			}
+			if err = migrator.MarkUnapplied(ctx, migration); err != nil {
+				return err
+			}
+			rolledBack = append(rolledBack, *migration)
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// migrationsStatus returns all known migrations with their applied status.
+func migrationsStatus(ctx context.Context, db *bun.DB) (migrate.MigrationSlice, error) {
+	var migrations migrate.MigrationSlice
+	err := withMigrationsLock(ctx, db, func(migrator *migrate.Migrator) error {
+		var err error
+		migrations, err = migrator.MigrationsWithStatus(ctx)
+		return err
+	})
+	return migrations, err
+}
+
+// runMigrateCommand executes the migrate subcommand of the server binary and reports the result to out.
+// Supported commands are status, up and down <migration|--all>.
+func runMigrateCommand(ctx context.Context, db *bun.DB, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errUnknownMigrateCommand
+	}
+	switch command := args[0]; command {
+	case "status":
+		migrations, err := migrationsStatus(ctx, db)
+		if err != nil {
+			return err
+		}
+		for _, migration := range migrations {
+			state := "pending"
+			if migration.IsApplied() {
+				state = fmt.Sprintf("applied at %s (group #%d)", migration.MigratedAt.Format(yyyy_mm_dd), migration.GroupID)
+			}
+			_, _ = fmt.Fprintf(out, "%s: %s\n", migration, state)
+		}
+		return nil
+	case "up":
+		group, err := migrateUp(ctx, db)
+		if err != nil {
+			return err
+		}
+		if group.IsZero() {
+			_, _ = fmt.Fprintln(out, "there are no new migrations to run")
+			return nil
+		}
+		_, _ = fmt.Fprintf(out, "migrated to %s\n", group)
+		return nil
+	case "down":
+		// rolling back without a target would drop the tasks table on a deployment that migrated in one group
+		if len(args) != 2 {
+			return errMissingMigrateTarget
+		}
+		rolledBack, err := migrateDown(ctx, db, args[1])
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			_, _ = fmt.Fprintln(out, "there are no migrations to roll back")
+			return nil
+		}
+		_, _ = fmt.Fprintf(out, "rolled back %s\n", rolledBack)
+		return nil
+	default:
+		return errUnknownMigrateCommand
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	ms "github.com/TekClinic/MicroService-Lib"
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// Notification channels, as stored in the database.
+const (
+	NotificationChannelInApp = "in_app"
+	NotificationChannelEmail = "email"
+	NotificationChannelSMS   = "sms"
+)
+
+// NotificationPreferences defines a schema of the notification preferences of users.
+// Users without preferences get in-app notifications only.
+type NotificationPreferences struct {
+	bun.BaseModel `bun:"table:task_notification_preferences"`
+
+	// Subject is the subject of the user the preferences belong to.
+	Subject string `bun:",pk"`
+	// Channels are the channels the user is notified by, empty means the user is never notified.
+	Channels []string `bun:",array"`
+	Email    string   `bun:",nullzero" validate:"omitempty,email,max=254"`
+	// Phone is an E.164 phone number, e.g. +972501234567.
+	Phone string `bun:",nullzero" validate:"omitempty,e164"`
+	// RemindBeforeMinutes is how long before the due date reminders are sent. Zero means defaultReminderLeadTime.
+	RemindBeforeMinutes int32     `bun:",notnull" validate:"min=0,max=10080"`
+	UpdatedAt           time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// defaultNotificationPreferences returns the preferences of a user that hasn't set any.
+func defaultNotificationPreferences(subject string) NotificationPreferences {
+	return NotificationPreferences{Subject: subject, Channels: []string{NotificationChannelInApp}}
+}
+
+// hasChannel reports whether the user is notified by the channel.
+func (preferences NotificationPreferences) hasChannel(channel string) bool {
+	for _, enabled := range preferences.Channels {
+		if enabled == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// toGRPC returns a GRPC version of NotificationPreferences.
+func (preferences NotificationPreferences) toGRPC() *ppb.NotificationPreferences {
+	channels := make([]ppb.NotificationChannel, len(preferences.Channels))
+	for i, channel := range preferences.Channels {
+		switch channel {
+		case NotificationChannelInApp:
+			channels[i] = ppb.NotificationChannel_NOTIFICATION_CHANNEL_IN_APP
+		case NotificationChannelEmail:
+			channels[i] = ppb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL
+		case NotificationChannelSMS:
+			channels[i] = ppb.NotificationChannel_NOTIFICATION_CHANNEL_SMS
+		}
+	}
+	return &ppb.NotificationPreferences{
+		Channels:            channels,
+		Email:               preferences.Email,
+		Phone:               preferences.Phone,
+		RemindBeforeMinutes: preferences.RemindBeforeMinutes,
+	}
+}
+
+// notificationChannelFromGRPC converts a GRPC notification channel to the one stored in the database.
+func notificationChannelFromGRPC(channel ppb.NotificationChannel) (string, error) {
+	switch channel {
+	case ppb.NotificationChannel_NOTIFICATION_CHANNEL_IN_APP:
+		return NotificationChannelInApp, nil
+	case ppb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL:
+		return NotificationChannelEmail, nil
+	case ppb.NotificationChannel_NOTIFICATION_CHANNEL_SMS:
+		return NotificationChannelSMS, nil
+	case ppb.NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED:
+		return "", errors.New("notification channel is not specified")
+	default:
+		return "", fmt.Errorf("unknown notification channel %d", channel)
+	}
+}
+
+// Notification defines a schema of in-app notifications.
+type Notification struct {
+	bun.BaseModel `bun:"table:task_notifications"`
+
+	Id int64 `bun:",pk,autoincrement"`
+	// Recipient is the subject of the notified user.
+	Recipient string    `bun:",notnull"`
+	TaskId    int32     `bun:",notnull"`
+	Message   string    `bun:",notnull"`
+	ReadAt    time.Time `bun:",nullzero"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// toGRPC returns a GRPC version of Notification.
+func (notification Notification) toGRPC() *ppb.Notification {
+	return &ppb.Notification{
+		Id:        notification.Id,
+		TaskId:    notification.TaskId,
+		Message:   notification.Message,
+		Read:      !notification.ReadAt.IsZero(),
+		CreatedAt: formatTimestamp(notification.CreatedAt),
+	}
+}
+
+// notifier sends notifications to users over a single channel.
+type notifier interface {
+	// notify sends the message about the task to the user with the given preferences.
+	notify(ctx context.Context, recipient NotificationPreferences, task *Task, message string) error
+}
+
+// notifiers are the notifiers of the channels that are configured, by channel.
+type notifiers map[string]notifier
+
+// createNotifiers returns the notifiers configured by the environment.
+// In-app notifications are always available, email and SMS ones only if their environment variables are set.
+func createNotifiers(db *bun.DB) (notifiers, error) {
+	configured := notifiers{NotificationChannelInApp: inAppNotifier{db: db}}
+	if addr := ms.GetOptionalEnv(envSMTPAddress, ""); addr != "" {
+		from := ms.GetOptionalEnv(envSMTPFrom, "")
+		if from == "" {
+			return nil, fmt.Errorf("%s is required when %s is set", envSMTPFrom, envSMTPAddress)
+		}
+		var auth smtp.Auth
+		if user := ms.GetOptionalEnv(envSMTPUser, ""); user != "" {
+			host, _, _ := strings.Cut(addr, ":")
+			auth = smtp.PlainAuth("", user, ms.GetOptionalEnv(envSMTPPassword, ""), host)
+		}
+		configured[NotificationChannelEmail] = emailNotifier{addr: addr, from: from, auth: auth}
+	}
+	if url := ms.GetOptionalEnv(envSMSGatewayURL, ""); url != "" {
+		configured[NotificationChannelSMS] = smsNotifier{url: url, client: &http.Client{Timeout: sinkTimeout}}
+	}
+	return configured, nil
+}
+
+// inAppNotifier stores notifications in the database, users fetch them with GetMyNotifications.
+type inAppNotifier struct {
+	db bun.IDB
+}
+
+// notify stores the notification.
+func (inApp inAppNotifier) notify(ctx context.Context, recipient NotificationPreferences, task *Task,
+	message string) error {
+	_, err := inApp.db.NewInsert().
+		Model(&Notification{Recipient: recipient.Subject, TaskId: task.Id, Message: message}).
+		Exec(ctx)
+	return err
+}
+
+// emailNotifier sends notifications as plain text emails over SMTP.
+type emailNotifier struct {
+	addr string
+	from string
+	// auth is nil if the server doesn't require authentication.
+	auth smtp.Auth
+}
+
+// notify sends the email. The recipient has to have an email address.
+func (email emailNotifier) notify(_ context.Context, recipient NotificationPreferences, task *Task,
+	message string) error {
+	if recipient.Email == "" {
+		return errors.New("recipient has no email address")
+	}
+	var msg bytes.Buffer
+	msg.WriteString("From: " + email.from + "\r\n")
+	msg.WriteString("To: " + recipient.Email + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", "Task reminder: "+task.Title) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(message + "\r\n")
+	return smtp.SendMail(email.addr, email.auth, email.from, []string{recipient.Email}, msg.Bytes())
+}
+
+// smsNotifier sends notifications through an SMS gateway that accepts {"to": ..., "message": ...} JSON POST requests.
+type smsNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// notify posts the message to the gateway. The recipient has to have a phone number.
+func (sms smsNotifier) notify(ctx context.Context, recipient NotificationPreferences, _ *Task,
+	message string) error {
+	if recipient.Phone == "" {
+		return errors.New("recipient has no phone number")
+	}
+	body, err := json.Marshal(map[string]string{"to": recipient.Phone, "message": message})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sms.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := sms.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("SMS gateway responded with %s", res.Status)
+	}
+	return nil
+}
+
+// send notifies the recipient by all of their channels that are configured.
+// Channels that are not configured are skipped. An error is returned only if no channel succeeded.
+func (configured notifiers) send(ctx context.Context, recipient NotificationPreferences, task *Task,
+	message string) error {
+	var errs []error
+	sent := false
+	for _, channel := range recipient.Channels {
+		channelNotifier, ok := configured[channel]
+		if !ok {
+			zap.L().Warn("Notification channel is not configured", zap.String("channel", channel))
+			continue
+		}
+		if err := channelNotifier.notify(ctx, recipient, task, message); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify by %s: %w", channel, err))
+			continue
+		}
+		sent = true
+	}
+	if !sent && len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	for _, err := range errs {
+		zap.L().Warn("Failed to send a notification", zap.String("recipient", recipient.Subject), zap.Error(err))
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// fakeNotifier records the messages it is asked to send and fails with err.
+type fakeNotifier struct {
+	messages []string
+	err      error
+}
+
+func (fake *fakeNotifier) notify(_ context.Context, _ NotificationPreferences, _ *Task, message string) error {
+	fake.messages = append(fake.messages, message)
+	return fake.err
+}
+
+func TestNotifiersSend(t *testing.T) {
+	errDown := errors.New("channel is down")
+	tests := []struct {
+		name     string
+		channels []string
+		failing  []string
+		wantErr  bool
+		wantSent []string
+	}{
+		{name: "all channels", channels: []string{"in_app", "email"}, wantSent: []string{"in_app", "email"}},
+		{name: "one channel fails", channels: []string{"in_app", "email"}, failing: []string{"email"},
+			wantSent: []string{"in_app", "email"}},
+		{name: "every channel fails", channels: []string{"in_app", "email"}, failing: []string{"in_app", "email"},
+			wantErr: true, wantSent: []string{"in_app", "email"}},
+		{name: "channel is not configured", channels: []string{"sms", "in_app"}, wantSent: []string{"in_app"}},
+		{name: "only an unconfigured channel", channels: []string{"sms"}},
+		{name: "no channels"},
+	}
+	for _, test := range tests {
+		inApp, email := &fakeNotifier{}, &fakeNotifier{}
+		for _, channel := range test.failing {
+			map[string]*fakeNotifier{"in_app": inApp, "email": email}[channel].err = errDown
+		}
+		configured := notifiers{NotificationChannelInApp: inApp, NotificationChannelEmail: email}
+		recipient := NotificationPreferences{Subject: "user", Channels: test.channels}
+
+		err := configured.send(context.Background(), recipient, &Task{Id: 1}, "reminder")
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: send = %v, want an error: %t", test.name, err, test.wantErr)
+		}
+		if test.wantErr && !errors.Is(err, errDown) {
+			t.Errorf("%s: send = %v, want it to wrap %v", test.name, err, errDown)
+		}
+		var sent []string
+		if len(inApp.messages) > 0 {
+			sent = append(sent, "in_app")
+		}
+		if len(email.messages) > 0 {
+			sent = append(sent, "email")
+		}
+		if strings.Join(sent, ",") != strings.Join(test.wantSent, ",") {
+			t.Errorf("%s: notified by %v, want %v", test.name, sent, test.wantSent)
+		}
+	}
+}
+
+// smtpMessage is a message received by fakeSMTPServer.
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTPServer accepts a single SMTP session without extensions and sends the message it received to messages.
+func fakeSMTPServer(t *testing.T) (string, <-chan smtpMessage) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan smtpMessage, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		text := textproto.NewConn(conn)
+		var msg smtpMessage
+		_ = text.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			verb, arg, _ := strings.Cut(line, " ")
+			switch strings.ToUpper(verb) {
+			case "EHLO", "HELO":
+				_ = text.PrintfLine("250 localhost")
+			case "MAIL":
+				msg.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+				_ = text.PrintfLine("250 OK")
+			case "RCPT":
+				msg.to = append(msg.to, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+				_ = text.PrintfLine("250 OK")
+			case "DATA":
+				_ = text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				lines, err := text.ReadDotLines()
+				if err != nil {
+					return
+				}
+				msg.data = strings.Join(lines, "\n")
+				_ = text.PrintfLine("250 OK")
+				messages <- msg
+			case "QUIT":
+				_ = text.PrintfLine("221 Bye")
+				return
+			default:
+				_ = text.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+	return listener.Addr().String(), messages
+}
+
+func TestEmailNotifier(t *testing.T) {
+	addr, messages := fakeSMTPServer(t)
+	email := emailNotifier{addr: addr, from: "tasks@clinic.example"}
+	recipient := NotificationPreferences{Subject: "user", Email: "doctor@clinic.example"}
+	task := &Task{Id: 7, Title: "Call the patient"}
+
+	if err := email.notify(context.Background(), recipient, task, "Task #7 is due."); err != nil {
+		t.Fatalf("notify returned an error: %v", err)
+	}
+	msg := <-messages
+	if msg.from != "tasks@clinic.example" || len(msg.to) != 1 || msg.to[0] != "doctor@clinic.example" {
+		t.Errorf("mail from %q to %v, want from tasks@clinic.example to doctor@clinic.example", msg.from, msg.to)
+	}
+	for _, want := range []string{"To: doctor@clinic.example", "Subject: Task reminder: Call the patient",
+		"Content-Type: text/plain; charset=utf-8", "Task #7 is due."} {
+		if !strings.Contains(msg.data, want) {
+			t.Errorf("mail %q doesn't contain %q", msg.data, want)
+		}
+	}
+
+	if err := email.notify(context.Background(), NotificationPreferences{Subject: "user"}, task, "x"); err == nil {
+		t.Error("notify of a recipient without an email address succeeded")
+	}
+}
+
+func TestSMSNotifier(t *testing.T) {
+	var received []map[string]string
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request content type = %q, want application/json", r.Header.Get("Content-Type"))
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode the request: %v", err)
+		}
+		received = append(received, body)
+		if body["to"] == "+972500000000" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer gateway.Close()
+	sms := smsNotifier{url: gateway.URL, client: gateway.Client()}
+	task := &Task{Id: 7}
+
+	recipient := NotificationPreferences{Subject: "user", Phone: "+972501234567"}
+	if err := sms.notify(context.Background(), recipient, task, "Task #7 is due."); err != nil {
+		t.Fatalf("notify returned an error: %v", err)
+	}
+	if len(received) != 1 || received[0]["to"] != "+972501234567" || received[0]["message"] != "Task #7 is due." {
+		t.Errorf("gateway received %v, want the message to +972501234567", received)
+	}
+
+	recipient.Phone = "+972500000000"
+	if err := sms.notify(context.Background(), recipient, task, "x"); err == nil {
+		t.Error("notify succeeded although the gateway failed")
+	}
+	if err := sms.notify(context.Background(), NotificationPreferences{Subject: "user"}, task, "x"); err == nil {
+		t.Error("notify of a recipient without a phone number succeeded")
+	}
+	if len(received) != 2 {
+		t.Errorf("gateway received %d requests, want 2", len(received))
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	ms "github.com/TekClinic/MicroService-Lib"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// outboxRelayInterval is how often the relay looks for outbox events to publish.
+	outboxRelayInterval = 5 * time.Second
+	// outboxBatchSize is the maximum number of outbox events published on each run of the relay.
+	outboxBatchSize = 100
+	// outboxMaxRetryDelay caps the exponential backoff between attempts to publish an event.
+	outboxMaxRetryDelay = time.Hour
+	// outboxMaxAttempts is the number of failed attempts after which an event is dead-lettered
+	// and not retried anymore, about a day of attempts.
+	outboxMaxAttempts = 35
+	// outboxLease is how long a replica has to publish the events it claimed, before others may claim them again.
+	outboxLease = 2 * time.Minute
+	// outboxRetention is how long published and dead-lettered events are kept in the outbox.
+	outboxRetention = 7 * 24 * time.Hour
+	// sinkTimeout bounds a single attempt to publish an event.
+	sinkTimeout = 10 * time.Second
+)
+
+// OutboxEvent defines a schema of the outbox: domain events waiting to be published to other services.
+// Events are written in the transaction of the change, so an event is published if and only if the change is committed.
+type OutboxEvent struct {
+	bun.BaseModel `bun:"table:task_outbox"`
+
+	Id     int64  `bun:",pk,autoincrement"`
+	TaskId int32  `bun:",notnull"`
+	Type   string `bun:",notnull"`
+	// Payload is the task after the change, in the JSON encoding of the GRPC version.
+	Payload   json.RawMessage `bun:"type:jsonb,notnull"`
+	Attempts  int32           `bun:",notnull"`
+	LastError string          `bun:",nullzero"`
+	// NextAttemptAt is when the event is due, it is pushed forward while a relay holds the lease of the event.
+	NextAttemptAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	PublishedAt   time.Time `bun:",nullzero"`
+	// DeadAt is when the event was given up on after outboxMaxAttempts failed attempts.
+	DeadAt    time.Time `bun:",nullzero"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// settle records the result of an attempt to publish the event made at now: the event is either published,
+// scheduled to be retried with an exponential backoff, or dead-lettered after outboxMaxAttempts failures.
+func (event *OutboxEvent) settle(err error, now time.Time) {
+	if err == nil {
+		event.PublishedAt = now
+		return
+	}
+	event.Attempts++
+	event.LastError = err.Error()
+	if event.Attempts >= outboxMaxAttempts {
+		event.DeadAt = now
+		return
+	}
+	event.NextAttemptAt = now.Add(retryDelay(event.Attempts))
+}
+
+// domainEvent is an event as it is delivered to other services.
+// Events are delivered at least once, consumers can use the id to skip duplicates.
+type domainEvent struct {
+	Id         int64           `json:"id"`
+	Type       string          `json:"type"`
+	TaskId     int32           `json:"task_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Task       json.RawMessage `json:"task"`
+}
+
+// toDomainEvent returns the event that is delivered for the outbox event.
+func (event OutboxEvent) toDomainEvent() domainEvent {
+	return domainEvent{
+		Id:         event.Id,
+		Type:       event.Type,
+		TaskId:     event.TaskId,
+		OccurredAt: event.CreatedAt,
+		Task:       event.Payload,
+	}
+}
+
+// eventSink delivers domain events to other services, e.g. over HTTP or a message broker like NATS or Kafka.
+type eventSink interface {
+	// publish delivers the event. An error means the event was not delivered and will be retried.
+	publish(ctx context.Context, event domainEvent) error
+}
+
+// webhookSink delivers events as JSON POST requests to a fixed URL.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// newWebhookSink returns a sink that posts events to the given URL.
+func newWebhookSink(url string) webhookSink {
+	return webhookSink{url: url, client: &http.Client{Timeout: sinkTimeout}}
+}
+
+// publish posts the event, any response status other than 2xx is an error.
+func (sink webhookSink) publish(ctx context.Context, event domainEvent) error {
+	_, err := postEvent(ctx, sink.client, sink.url, "", event)
+	return err
+}
+
+// logSink only logs the events. It is used when no other sink is configured, so the outbox doesn't grow.
+type logSink struct{}
+
+// publish logs the event.
+func (logSink) publish(_ context.Context, event domainEvent) error {
+	zap.L().Info("Task event", zap.Int64("id", event.Id), zap.String("type", event.Type),
+		zap.Int32("task_id", event.TaskId))
+	return nil
+}
+
+// createEventSink returns the sink that is configured by the environment, logSink if none is.
+func createEventSink() eventSink {
+	if url := ms.GetOptionalEnv(envEventsWebhookURL, ""); url != "" {
+		return newWebhookSink(url)
+	}
+	zap.L().Info(envEventsWebhookURL + " is not set, task events are only logged")
+	return logSink{}
+}
+
+// enqueueDomainEvent writes an event of the task to the outbox, to be published by the relay once tx commits,
+// and to the deliveries of the webhooks that are subscribed to it.
+func enqueueDomainEvent(ctx context.Context, tx bun.Tx, task *Task, eventType string) error {
+	payload, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(task.toGRPC())
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to encode a task event: %w", err).Error())
+	}
+	event := OutboxEvent{
+		TaskId:  task.Id,
+		Type:    domainEventType(eventType),
+		Payload: payload,
+	}
+	if _, err = tx.NewInsert().Model(&event).Exec(ctx); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to write a task event to the outbox: %w", err).Error())
+	}
+	return enqueueWebhookDeliveries(ctx, tx, event, eventType)
+}
+
+// domainEventType returns the type of domain events of the given task event type, e.g. task.created.
+func domainEventType(eventType string) string {
+	return "task." + eventType
+}
+
+// retryDelay returns how long to wait before the next attempt to publish an event that failed the given times.
+func retryDelay(attempts int32) time.Duration {
+	delay := time.Second
+	for i := int32(1); i < attempts && delay < outboxMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxMaxRetryDelay)
+}
+
+// runOutboxRelay publishes the outbox events to the sink until ctx is done.
+// Every replica runs the relay, replicas skip the events that are being published by others.
+func runOutboxRelay(ctx context.Context, db *bun.DB, sink eventSink) {
+	ticker := time.NewTicker(outboxRelayInterval)
+	defer ticker.Stop()
+	for {
+		if err := relayOutbox(ctx, db, sink); err != nil {
+			zap.L().Error("Failed to relay outbox events", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayOutbox publishes a batch of due outbox events in the order they were written, and removes old ones.
+// The events are claimed with a lease in a short transaction and published outside of it, so no rows are locked
+// while the sink is called. If the relay stops before the results are written, the events are published again
+// once the lease expires. Events that fail are retried with an exponential backoff, so they may be delivered
+// after newer events.
+func relayOutbox(ctx context.Context, db *bun.DB, sink eventSink) error {
+	now := time.Now()
+	// Postgres keeps microseconds, the lease is compared to the stored one when the results are written
+	leaseUntil := now.Add(outboxLease).Truncate(time.Microsecond)
+	var events []OutboxEvent
+	if err := db.NewUpdate().
+		Model((*OutboxEvent)(nil)).
+		Set("next_attempt_at = ?", leaseUntil).
+		Where("? IN (?)", bun.Ident("id"), db.NewSelect().
+			Model((*OutboxEvent)(nil)).
+			Column("id").
+			Where("? IS NULL", bun.Ident("published_at")).
+			Where("? IS NULL", bun.Ident("dead_at")).
+			Where("? <= ?", bun.Ident("next_attempt_at"), now).
+			Order("id").
+			Limit(outboxBatchSize).
+			For("UPDATE SKIP LOCKED")).
+		Returning("*").
+		Scan(ctx, &events); err != nil {
+		return fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	slices.SortFunc(events, func(a, b OutboxEvent) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	attempted := publishOutboxEvents(ctx, sink, events, leaseUntil)
+	if err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		for i := range attempted {
+			// an event whose lease expired may have been claimed by another relay, its results win
+			if _, err := tx.NewUpdate().
+				Model(&attempted[i]).
+				Column("attempts", "last_error", "next_attempt_at", "published_at", "dead_at").
+				WherePK().
+				Where("? = ?", bun.Ident("next_attempt_at"), leaseUntil).
+				Exec(ctx); err != nil {
+				return fmt.Errorf("failed to update an outbox event: %w", err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-outboxRetention)
+	if _, err := db.NewDelete().
+		Model((*OutboxEvent)(nil)).
+		WhereOr("? < ?", bun.Ident("published_at"), cutoff).
+		WhereOr("? < ?", bun.Ident("dead_at"), cutoff).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to remove old outbox events: %w", err)
+	}
+	return nil
+}
+
+// publishOutboxEvents publishes the events to the sink in order and settles each of them with the result.
+// Publishing stops when an attempt could outlast the lease or ctx is done. The attempted events are returned.
+func publishOutboxEvents(ctx context.Context, sink eventSink, events []OutboxEvent,
+	leaseUntil time.Time) []OutboxEvent {
+	for i := range events {
+		if ctx.Err() != nil || time.Until(leaseUntil) < sinkTimeout {
+			return events[:i]
+		}
+		publishCtx, cancel := context.WithTimeout(ctx, sinkTimeout)
+		err := sink.publish(publishCtx, events[i].toDomainEvent())
+		cancel()
+		events[i].settle(err, time.Now())
+		switch {
+		case !events[i].DeadAt.IsZero():
+			zap.L().Error("Gave up publishing an outbox event", zap.Int64("id", events[i].Id),
+				zap.Int32("attempts", events[i].Attempts), zap.Error(err))
+		case err != nil:
+			zap.L().Warn("Failed to publish an outbox event", zap.Int64("id", events[i].Id),
+				zap.Int32("attempts", events[i].Attempts), zap.Error(err))
+		}
+	}
+	return events
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeSink is an in-process eventSink that records the published events and fails the ones it is told to.
+type fakeSink struct {
+	published []domainEvent
+	// failures are the errors returned for the events with the given ids
+	failures map[int64]error
+}
+
+func (sink *fakeSink) publish(_ context.Context, event domainEvent) error {
+	if err := sink.failures[event.Id]; err != nil {
+		return err
+	}
+	sink.published = append(sink.published, event)
+	return nil
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{attempts: 0, want: time.Second},
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 10, want: 512 * time.Second},
+		{attempts: 12, want: 2048 * time.Second},
+		{attempts: 13, want: time.Hour},
+		{attempts: 1000, want: time.Hour},
+	}
+	for _, test := range tests {
+		if got := retryDelay(test.attempts); got != test.want {
+			t.Errorf("retryDelay(%d) = %s, want %s", test.attempts, got, test.want)
+		}
+	}
+}
+
+func TestOutboxEventSettle(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	errSink := errors.New("sink is down")
+	tests := []struct {
+		name          string
+		attempts      int32
+		err           error
+		wantAttempts  int32
+		wantPublished bool
+		wantDead      bool
+		wantNext      time.Time
+	}{
+		{name: "published", attempts: 0, wantPublished: true},
+		{name: "published after failures", attempts: 3, wantAttempts: 3, wantPublished: true},
+		{name: "first failure", attempts: 0, err: errSink, wantAttempts: 1, wantNext: now.Add(time.Second)},
+		{name: "backoff", attempts: 4, err: errSink, wantAttempts: 5, wantNext: now.Add(16 * time.Second)},
+		{
+			name:         "backoff is capped",
+			attempts:     outboxMaxAttempts - 2,
+			err:          errSink,
+			wantAttempts: outboxMaxAttempts - 1,
+			wantNext:     now.Add(outboxMaxRetryDelay),
+		},
+		{
+			name:         "dead-lettered",
+			attempts:     outboxMaxAttempts - 1,
+			err:          errSink,
+			wantAttempts: outboxMaxAttempts,
+			wantDead:     true,
+		},
+	}
+	for _, test := range tests {
+		event := OutboxEvent{Id: 1, Attempts: test.attempts}
+		event.settle(test.err, now)
+		if event.Attempts != test.wantAttempts {
+			t.Errorf("%s: attempts = %d, want %d", test.name, event.Attempts, test.wantAttempts)
+		}
+		if published := !event.PublishedAt.IsZero(); published != test.wantPublished {
+			t.Errorf("%s: published = %t, want %t", test.name, published, test.wantPublished)
+		}
+		if dead := !event.DeadAt.IsZero(); dead != test.wantDead {
+			t.Errorf("%s: dead = %t, want %t", test.name, dead, test.wantDead)
+		}
+		if !event.NextAttemptAt.Equal(test.wantNext) {
+			t.Errorf("%s: next attempt at %s, want %s", test.name, event.NextAttemptAt, test.wantNext)
+		}
+		if test.err != nil && event.LastError != test.err.Error() {
+			t.Errorf("%s: last error = %q, want %q", test.name, event.LastError, test.err.Error())
+		}
+	}
+}
+
+func TestPublishOutboxEvents(t *testing.T) {
+	sink := &fakeSink{failures: map[int64]error{2: errors.New("rejected")}}
+	events := []OutboxEvent{
+		{Id: 1, Type: "task.created", TaskId: 10},
+		{Id: 2, Type: "task.updated", TaskId: 10},
+		{Id: 3, Type: "task.completed", TaskId: 11, Attempts: 2},
+	}
+
+	attempted := publishOutboxEvents(context.Background(), sink, events, time.Now().Add(outboxLease))
+	if len(attempted) != len(events) {
+		t.Fatalf("attempted %d events, want %d", len(attempted), len(events))
+	}
+	if len(sink.published) != 2 || sink.published[0].Id != 1 || sink.published[1].Id != 3 {
+		t.Errorf("published %+v, want events 1 and 3 in order", sink.published)
+	}
+	if attempted[0].PublishedAt.IsZero() || attempted[2].PublishedAt.IsZero() {
+		t.Error("successfully published events are not marked as published")
+	}
+	if failed := attempted[1]; !failed.PublishedAt.IsZero() || failed.Attempts != 1 || failed.LastError != "rejected" ||
+		failed.NextAttemptAt.IsZero() {
+		t.Errorf("failed event = %+v, want it scheduled for a retry", failed)
+	}
+}
+
+func TestPublishOutboxEventsStopsBeforeTheLeaseExpires(t *testing.T) {
+	sink := &fakeSink{}
+	events := []OutboxEvent{{Id: 1}, {Id: 2}}
+
+	attempted := publishOutboxEvents(context.Background(), sink, events, time.Now().Add(sinkTimeout/2))
+	if len(attempted) != 0 || len(sink.published) != 0 {
+		t.Errorf("attempted %d events with an expiring lease, want none", len(attempted))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if attempted = publishOutboxEvents(ctx, sink, events, time.Now().Add(outboxLease)); len(attempted) != 0 {
+		t.Errorf("attempted %d events after the context was done, want none", len(attempted))
+	}
+}
+
+func TestDomainEventType(t *testing.T) {
+	for eventType, want := range map[string]string{
+		TaskEventCreated:   "task.created",
+		TaskEventCompleted: "task.completed",
+		TaskEventAssigned:  "task.assigned",
+	} {
+		if got := domainEventType(eventType); got != want {
+			t.Errorf("domainEventType(%q) = %q, want %q", eventType, got, want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errInvalidPageToken = errors.New("page token is invalid")
+
+// sortKey is an SQL expression that task listings are ordered by.
+// Expressions never evaluate to NULL, so that they can be compared in keyset conditions.
+type sortKey struct {
+	// expr is an SQL expression over the task table.
+	expr string
+	// sqlType is the type that a text representation of the expression value is cast back to.
+	sqlType    string
+	descending bool
+}
+
+// taskSorting is an ordering of a task listing.
+// The last key is always the task id, which makes the ordering total and lets pages be resumed from any row.
+type taskSorting struct {
+	keys []sortKey
+	// signature identifies the ordering, so that a page token can't be used with a different one.
+	signature string
+}
+
+// taskPageRow is a listed task along with the values of its sort keys.
+type taskPageRow struct {
+	Task     `bun:",extend"`
+	SortKeys []string `bun:",array"`
+}
+
+// pageCursor is a position in an ordered task listing. It is sent to clients as an opaque page token.
+type pageCursor struct {
+	Signature string   `json:"s"`
+	SortKeys  []string `json:"k"`
+}
+
+// encodePageToken encodes the position right after a row with the given sort keys into a page token.
+func encodePageToken(signature string, sortKeys []string) (string, error) {
+	raw, err := json.Marshal(pageCursor{Signature: signature, SortKeys: sortKeys})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodePageToken decodes a page token produced by encodePageToken for the same ordering,
+// which has the given number of sort keys.
+func decodePageToken(signature string, keyCount int, token string) (pageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageCursor{}, errInvalidPageToken
+	}
+	var cursor pageCursor
+	if err = json.Unmarshal(raw, &cursor); err != nil {
+		return pageCursor{}, errInvalidPageToken
+	}
+	if cursor.Signature != signature || len(cursor.SortKeys) != keyCount {
+		return pageCursor{}, errors.New("page token doesn't match the requested ordering")
+	}
+	return cursor, nil
+}
+
+// apply orders the query by the sort keys and selects their values as text into the sort_keys column.
+func (sorting taskSorting) apply(query *bun.SelectQuery) *bun.SelectQuery {
+	values := make([]string, len(sorting.keys))
+	for i, key := range sorting.keys {
+		direction := "ASC"
+		if key.descending {
+			direction = "DESC"
+		}
+		query = query.OrderExpr(key.expr + " " + direction)
+		values[i] = "(" + key.expr + ")::text"
+	}
+	return query.ColumnExpr("ARRAY[" + strings.Join(values, ", ") + "] AS sort_keys")
+}
+
+// after narrows down the query to the rows that come after the cursor in the ordering.
+func (sorting taskSorting) after(query *bun.SelectQuery, cursor pageCursor) *bun.SelectQuery {
+	// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ..., with < instead of > for descending keys
+	conditions := make([]string, len(sorting.keys))
+	var args []interface{}
+	for i, key := range sorting.keys {
+		parts := make([]string, 0, i+1)
+		for j, previous := range sorting.keys[:i] {
+			parts = append(parts, previous.expr+" = ?::"+previous.sqlType)
+			args = append(args, cursor.SortKeys[j])
+		}
+		operator := ">"
+		if key.descending {
+			operator = "<"
+		}
+		parts = append(parts, key.expr+" "+operator+" ?::"+key.sqlType)
+		args = append(args, cursor.SortKeys[i])
+		conditions[i] = "(" + strings.Join(parts, " AND ") + ")"
+	}
+	return query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+}
+
+// fetchTasksPage fetches up to limit rows of the query in the given ordering, starting after pageToken if it is set.
+// If there are more rows after the page, a token of the next page is returned as well.
+// If the page token is not valid, codes.InvalidArgument is returned.
+func fetchTasksPage(ctx context.Context, query *bun.SelectQuery, sorting taskSorting, limit int,
+	pageToken string) ([]taskPageRow, string, error) {
+	if pageToken != "" {
+		cursor, err := decodePageToken(sorting.signature, len(sorting.keys), pageToken)
+		if err != nil {
+			return nil, "", status.Error(codes.InvalidArgument, err.Error())
+		}
+		query = sorting.after(query, cursor)
+	}
+
+	var rows []taskPageRow
+	// fetch one extra row to know whether there is a next page
+	if err := sorting.apply(query).Limit(limit+1).Scan(ctx, &rows); err != nil {
+		return nil, "", status.Error(codes.Internal, fmt.Errorf("failed to fetch tasks: %w", err).Error())
+	}
+	if len(rows) <= limit {
+		return rows, "", nil
+	}
+	rows = rows[:limit]
+	nextPageToken, err := encodePageToken(sorting.signature, rows[len(rows)-1].SortKeys)
+	if err != nil {
+		return nil, "", status.Error(codes.Internal, fmt.Errorf("failed to create a page token: %w", err).Error())
+	}
+	return rows, nextPageToken, nil
+}
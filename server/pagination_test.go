@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/base64"
+	"slices"
+	"testing"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		sortKeys []string
+	}{
+		{name: "id only", sortKeys: []string{"42"}},
+		{name: "due date", sortKeys: []string{"2025-01-01 12:00:00+00", "42"}},
+		{name: "infinity", sortKeys: []string{"infinity", "7"}},
+		{name: "title with quotes", sortKeys: []string{`say "hi", it's me`, "3"}},
+		{name: "empty key", sortKeys: []string{"", "1"}},
+	}
+	for _, test := range tests {
+		token, err := encodePageToken("1/false/false", test.sortKeys)
+		if err != nil {
+			t.Fatalf("%s: encodePageToken returned an error: %v", test.name, err)
+		}
+		cursor, err := decodePageToken("1/false/false", len(test.sortKeys), token)
+		if err != nil {
+			t.Errorf("%s: decodePageToken returned an error: %v", test.name, err)
+			continue
+		}
+		if !slices.Equal(cursor.SortKeys, test.sortKeys) {
+			t.Errorf("%s: decoded sort keys %q, want %q", test.name, cursor.SortKeys, test.sortKeys)
+		}
+	}
+}
+
+func TestDecodePageToken(t *testing.T) {
+	token, err := encodePageToken("2/true/false", []string{"3", "42"})
+	if err != nil {
+		t.Fatalf("encodePageToken returned an error: %v", err)
+	}
+	tests := []struct {
+		name      string
+		signature string
+		keyCount  int
+		token     string
+		wantErr   bool
+	}{
+		{name: "matching ordering", signature: "2/true/false", keyCount: 2, token: token},
+		{name: "other direction", signature: "2/false/false", keyCount: 2, token: token, wantErr: true},
+		{name: "other ordering", signature: "3/true/false", keyCount: 2, token: token, wantErr: true},
+		{name: "search", signature: "2/true/true", keyCount: 3, token: token, wantErr: true},
+		{name: "other key count", signature: "2/true/false", keyCount: 3, token: token, wantErr: true},
+		{name: "comments token", signature: commentsSignature, keyCount: 2, token: token, wantErr: true},
+		{name: "not base64", signature: "2/true/false", keyCount: 2, token: "not a token!", wantErr: true},
+		{
+			name:      "not json",
+			signature: "2/true/false",
+			keyCount:  2,
+			token:     base64.RawURLEncoding.EncodeToString([]byte("{")),
+			wantErr:   true,
+		},
+		{name: "empty", signature: "2/true/false", keyCount: 2, token: "", wantErr: true},
+	}
+	for _, test := range tests {
+		_, err := decodePageToken(test.signature, test.keyCount, test.token)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: decodePageToken = %v, want an error: %t", test.name, err, test.wantErr)
+		}
+	}
+}
+
+func TestTaskSortingSignature(t *testing.T) {
+	signatures := map[string]string{}
+	for _, orderBy := range []ppb.TaskOrderBy{ppb.TaskOrderBy_TASK_ORDER_BY_ID, ppb.TaskOrderBy_TASK_ORDER_BY_DUE_AT,
+		ppb.TaskOrderBy_TASK_ORDER_BY_PRIORITY, ppb.TaskOrderBy_TASK_ORDER_BY_CREATED_AT,
+		ppb.TaskOrderBy_TASK_ORDER_BY_TITLE} {
+		for _, descending := range []bool{false, true} {
+			for _, search := range []bool{false, true} {
+				sorting, err := taskSortingFor(orderBy, descending, search)
+				if err != nil {
+					t.Fatalf("taskSortingFor returned an error: %v", err)
+				}
+				name := orderBy.String()
+				if descending {
+					name += " descending"
+				}
+				if search {
+					name += " with search"
+				}
+				if other, ok := signatures[sorting.signature]; ok {
+					t.Errorf("%s and %s have the same signature %q", name, other, sorting.signature)
+				}
+				signatures[sorting.signature] = name
+			}
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ms "github.com/TekClinic/MicroService-Lib"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// patientsServiceName is the name of the patients microservice, its address is configured by
+	// MS_PATIENTS_HOST and MS_PATIENTS_PORT, see ms.FetchServiceParameters.
+	patientsServiceName = "patients"
+	// patientsGetPatientMethod is the RPC that fetches a patient. It returns codes.NotFound for patients
+	// that don't exist or were deleted.
+	patientsGetPatientMethod = "/patients.PatientsService/GetPatient"
+	// patientsTimeout bounds a single call to the patients microservice.
+	patientsTimeout = 3 * time.Second
+	// patientsCacheTTL is how long it is remembered that a patient exists.
+	patientsCacheTTL = 5 * time.Minute
+	// patientsMissingCacheTTL is how long it is remembered that a patient doesn't exist,
+	// shorter than patientsCacheTTL so that new patients can be used soon.
+	patientsMissingCacheTTL = 30 * time.Second
+	// patientsCacheSize is the maximum number of cached patients, the cache is cleared when it is full.
+	patientsCacheSize = 10_000
+	// patientsDegradedFor is how long the patients microservice isn't called after it was unavailable.
+	patientsDegradedFor = 30 * time.Second
+)
+
+var errPatientsUnavailable = errors.New("patients service is unavailable")
+
+// rawCodec passes already encoded messages to gRPC as is.
+// Only the status of GetPatient matters, so its messages are encoded by hand instead of depending on the
+// generated code of the patients microservice.
+type rawCodec struct{}
+
+// Marshal returns the bytes pointed by v.
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	raw, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("raw codec can't marshal %T", v)
+	}
+	return *raw, nil
+}
+
+// Unmarshal stores data into the bytes pointed by v.
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	raw, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("raw codec can't unmarshal into %T", v)
+	}
+	*raw = append((*raw)[:0], data...)
+	return nil
+}
+
+// Name returns the name of the proto codec, the encoded messages are protobuf messages.
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// encodeGetPatientRequest encodes a GetPatientRequest{token = 1, id = 2} of the patients microservice.
+func encodeGetPatientRequest(token string, id int32) []byte {
+	var req []byte
+	req = protowire.AppendTag(req, 1, protowire.BytesType)
+	req = protowire.AppendString(req, token)
+	req = protowire.AppendTag(req, 2, protowire.VarintType)
+	req = protowire.AppendVarint(req, uint64(int64(id)))
+	return req
+}
+
+// patientCacheEntry is a cached answer whether a patient exists.
+type patientCacheEntry struct {
+	exists    bool
+	expiresAt time.Time
+}
+
+// patientsClient checks with the patients microservice that patients exist.
+// When the microservice is unavailable, the client is degraded for a while and fails fast.
+type patientsClient struct {
+	conn *grpc.ClientConn
+
+	mu               sync.Mutex
+	cache            map[int32]patientCacheEntry
+	unavailableUntil time.Time
+}
+
+// createPatientsClient returns a client of the patients microservice, or nil if MS_PATIENTS_HOST is not set.
+func createPatientsClient() (*patientsClient, error) {
+	if ms.GetOptionalEnv(envPatientsHost, "") == "" {
+		return nil, nil
+	}
+	service, err := ms.FetchServiceParameters(patientsServiceName)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.NewClient(service.GetAddr(), ms.GetGRPCClientOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a patients client: %w", err)
+	}
+	return &patientsClient{conn: conn, cache: map[int32]patientCacheEntry{}}, nil
+}
+
+// cached returns the cached answer whether the patient exists, and whether there is one.
+func (client *patientsClient) cached(id int32, now time.Time) (bool, bool) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	entry, ok := client.cache[id]
+	if !ok || now.After(entry.expiresAt) {
+		return false, false
+	}
+	return entry.exists, true
+}
+
+// remember caches the answer whether the patient exists.
+func (client *patientsClient) remember(id int32, exists bool, now time.Time) {
+	ttl := patientsMissingCacheTTL
+	if exists {
+		ttl = patientsCacheTTL
+	}
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if len(client.cache) >= patientsCacheSize {
+		client.cache = map[int32]patientCacheEntry{}
+	}
+	client.cache[id] = patientCacheEntry{exists: exists, expiresAt: now.Add(ttl)}
+}
+
+// forget removes the cached answer whether the patient exists, e.g. once the patient is archived or restored.
+func (client *patientsClient) forget(id int32) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	delete(client.cache, id)
+}
+
+// degraded reports whether the patients microservice was recently unavailable.
+func (client *patientsClient) degraded(now time.Time) bool {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return now.Before(client.unavailableUntil)
+}
+
+// degrade stops calling the patients microservice for patientsDegradedFor.
+func (client *patientsClient) degrade(now time.Time) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.unavailableUntil = now.Add(patientsDegradedFor)
+}
+
+// patientExists reports whether a patient with the given id exists and is not deleted.
+// The token is forwarded to the patients microservice, so it has to allow the caller to fetch patients.
+// If the microservice can't answer, errPatientsUnavailable or the error of the call is returned.
+func (client *patientsClient) patientExists(ctx context.Context, token string, id int32) (bool, error) {
+	now := time.Now()
+	if exists, ok := client.cached(id, now); ok {
+		return exists, nil
+	}
+	if client.degraded(now) {
+		return false, errPatientsUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, patientsTimeout)
+	defer cancel()
+	req := encodeGetPatientRequest(token, id)
+	var res []byte
+	err := client.conn.Invoke(ctx, patientsGetPatientMethod, &req, &res, grpc.ForceCodec(rawCodec{}))
+	switch status.Code(err) {
+	case codes.OK:
+		client.remember(id, true, now)
+		return true, nil
+	case codes.NotFound:
+		client.remember(id, false, now)
+		return false, nil
+	case codes.Unavailable, codes.DeadlineExceeded:
+		client.degrade(now)
+		return false, fmt.Errorf("%w: %w", errPatientsUnavailable, err)
+	default:
+		return false, err
+	}
+}
+
+// checkPatient verifies that a patient with the given id exists, if the patients microservice is configured.
+// If the patient doesn't exist, codes.InvalidArgument is returned.
+// If the microservice is unavailable, the patient is assumed to exist, so tasks can be written while it is down.
+// Other errors of the microservice are returned with their code, e.g. codes.PermissionDenied if the caller
+// isn't allowed to fetch patients, or as codes.Internal if they have none.
+func (server tasksServer) checkPatient(ctx context.Context, id int32) error {
+	if server.patients == nil {
+		return nil
+	}
+	exists, err := server.patients.patientExists(ctx, callerToken(ctx), id)
+	if errors.Is(err, errPatientsUnavailable) {
+		zap.L().Warn("Failed to verify a patient, assuming it exists", zap.Int32("patient_id", id), zap.Error(err))
+		return nil
+	}
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+			return status.Error(st.Code(), fmt.Sprintf("failed to verify patient %d: %s", id, st.Message()))
+		}
+		return status.Error(codes.Internal, fmt.Errorf("failed to verify patient %d: %w", id, err).Error())
+	}
+	if !exists {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("patient %d is not found", id))
+	}
+	return nil
+}
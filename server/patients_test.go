@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestEncodeGetPatientRequest(t *testing.T) {
+	tests := []struct {
+		token string
+		id    int32
+	}{
+		{token: "token", id: 1},
+		{token: "", id: 300},
+		{token: "a.b.c", id: 2_147_483_647},
+		{token: "token", id: -1},
+	}
+	for _, test := range tests {
+		req := encodeGetPatientRequest(test.token, test.id)
+
+		var token string
+		var id int32
+		for len(req) > 0 {
+			num, typ, n := protowire.ConsumeTag(req)
+			if n < 0 {
+				t.Fatalf("request of %q, %d has an invalid tag: %v", test.token, test.id, protowire.ParseError(n))
+			}
+			req = req[n:]
+			switch {
+			case num == 1 && typ == protowire.BytesType:
+				var value string
+				value, n = protowire.ConsumeString(req)
+				token = value
+			case num == 2 && typ == protowire.VarintType:
+				var value uint64
+				value, n = protowire.ConsumeVarint(req)
+				id = int32(value)
+			default:
+				t.Fatalf("request of %q, %d has an unexpected field %d of type %d", test.token, test.id, num, typ)
+			}
+			if n < 0 {
+				t.Fatalf("request of %q, %d has an invalid value: %v", test.token, test.id, protowire.ParseError(n))
+			}
+			req = req[n:]
+		}
+		if token != test.token || id != test.id {
+			t.Errorf("request decoded to %q, %d, want %q, %d", token, id, test.token, test.id)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	ms "github.com/TekClinic/MicroService-Lib"
+)
+
+// permission is an action on tasks that can be granted to roles.
+type permission string
+
+// Permissions that are checked by the task microservice.
+const (
+	// permissionRead allows to fetch and list tasks and to claim unassigned tasks.
+	permissionRead permission = "tasks:read"
+	// permissionWrite allows to create and update tasks and to change the status of any task.
+	permissionWrite permission = "tasks:write"
+	// permissionAssign allows to assign tasks to other users and to unassign them.
+	permissionAssign permission = "tasks:assign"
+	// permissionDelete allows to delete, restore and purge tasks.
+	permissionDelete permission = "tasks:delete"
+	// permissionWebhooks allows to manage webhooks and to inspect their deliveries.
+	permissionWebhooks permission = "tasks:webhooks"
+	// permissionPatients allows to archive and restore all the tasks of a patient, meant for the patients service.
+	permissionPatients permission = "tasks:patients"
+)
+
+// defaultPermissionRole is the role that is granted every permission the policy doesn't configure.
+const defaultPermissionRole = "admin"
+
+// permissionPolicy maps permissions to the roles they are granted to.
+type permissionPolicy map[permission][]string
+
+// parsePermissionPolicy parses a policy in the form of "tasks:read=admin,doctor;tasks:write=admin".
+// Permissions that are not listed are granted to the admin role only, so an empty policy grants everything to admins.
+func parsePermissionPolicy(raw string) (permissionPolicy, error) {
+	policy := permissionPolicy{
+		permissionRead:     {defaultPermissionRole},
+		permissionWrite:    {defaultPermissionRole},
+		permissionAssign:   {defaultPermissionRole},
+		permissionDelete:   {defaultPermissionRole},
+		permissionWebhooks: {defaultPermissionRole},
+		permissionPatients: {defaultPermissionRole},
+	}
+	for _, rule := range strings.Split(raw, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		name, rawRoles, ok := strings.Cut(rule, "=")
+		if !ok {
+			return nil, fmt.Errorf("permission rule %q has to be in the form of permission=role,role", rule)
+		}
+		perm := permission(strings.TrimSpace(name))
+		if _, known := policy[perm]; !known {
+			return nil, fmt.Errorf("unknown permission %q", perm)
+		}
+		var roles []string
+		for _, role := range strings.Split(rawRoles, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles = append(roles, role)
+			}
+		}
+		policy[perm] = roles
+	}
+	return policy, nil
+}
+
+// allows reports whether any role of the claims is granted the permission.
+// Missing claims are granted nothing.
+func (policy permissionPolicy) allows(claims ms.Claims, perm permission) bool {
+	if claims == nil {
+		return false
+	}
+	for _, role := range policy[perm] {
+		if claims.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParsePermissionPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    map[permission][]string
+		wantErr bool
+	}{
+		{name: "empty", raw: "", want: map[permission][]string{permissionRead: {"admin"}, permissionPatients: {"admin"}}},
+		{
+			name: "some permissions",
+			raw:  "tasks:read=admin,doctor,volunteer;tasks:write=admin,doctor",
+			want: map[permission][]string{
+				permissionRead:   {"admin", "doctor", "volunteer"},
+				permissionWrite:  {"admin", "doctor"},
+				permissionAssign: {"admin"},
+			},
+		},
+		{
+			name: "spaces and empty rules",
+			raw:  " tasks:delete = admin , doctor ;; ",
+			want: map[permission][]string{permissionDelete: {"admin", "doctor"}},
+		},
+		{name: "granted to nobody", raw: "tasks:webhooks=", want: map[permission][]string{permissionWebhooks: nil}},
+		{name: "unknown permission", raw: "tasks:purge=admin", wantErr: true},
+		{name: "missing roles", raw: "tasks:read", wantErr: true},
+	}
+	for _, test := range tests {
+		policy, err := parsePermissionPolicy(test.raw)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: parsePermissionPolicy(%q) = %v, want an error", test.name, test.raw, policy)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: parsePermissionPolicy(%q) returned an error: %v", test.name, test.raw, err)
+			continue
+		}
+		if len(policy) != 6 {
+			t.Errorf("%s: policy has %d permissions, want all 6", test.name, len(policy))
+		}
+		for perm, roles := range test.want {
+			if !slices.Equal(policy[perm], roles) {
+				t.Errorf("%s: %s is granted to %q, want %q", test.name, perm, policy[perm], roles)
+			}
+		}
+	}
+}
+
+func TestPermissionPolicyAllowsNoClaims(t *testing.T) {
+	policy, err := parsePermissionPolicy("")
+	if err != nil {
+		t.Fatalf("parsePermissionPolicy returned an error: %v", err)
+	}
+	if policy.allows(nil, permissionRead) {
+		t.Error("missing claims are granted a permission")
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+)
+
+// TaskPriority is the urgency of a task.
+// It is stored as a number, so that greater values are more urgent and tasks can be sorted by it.
+type TaskPriority int16
+
+// Priorities a task can have, as stored in the database.
+const (
+	TaskPriorityLow TaskPriority = iota + 1
+	TaskPriorityNormal
+	TaskPriorityHigh
+	TaskPriorityUrgent
+)
+
+// toGRPC returns a GRPC version of TaskPriority.
+func (priority TaskPriority) toGRPC() ppb.Task_Priority {
+	switch priority {
+	case TaskPriorityLow:
+		return ppb.Task_PRIORITY_LOW
+	case TaskPriorityNormal:
+		return ppb.Task_PRIORITY_NORMAL
+	case TaskPriorityHigh:
+		return ppb.Task_PRIORITY_HIGH
+	case TaskPriorityUrgent:
+		return ppb.Task_PRIORITY_URGENT
+	default:
+		return ppb.Task_PRIORITY_UNSPECIFIED
+	}
+}
+
+// taskPriorityFromGRPC returns a TaskPriority from a GRPC version.
+// An unspecified priority is returned as zero, so that callers can pick a default.
+func taskPriorityFromGRPC(priority ppb.Task_Priority) (TaskPriority, error) {
+	switch priority {
+	case ppb.Task_PRIORITY_UNSPECIFIED:
+		return 0, nil
+	case ppb.Task_PRIORITY_LOW:
+		return TaskPriorityLow, nil
+	case ppb.Task_PRIORITY_NORMAL:
+		return TaskPriorityNormal, nil
+	case ppb.Task_PRIORITY_HIGH:
+		return TaskPriorityHigh, nil
+	case ppb.Task_PRIORITY_URGENT:
+		return TaskPriorityUrgent, nil
+	default:
+		return 0, fmt.Errorf("unknown task priority %d", priority)
+	}
+}
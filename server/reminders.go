@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+const (
+	// remindersLockID is the key of the Postgres advisory lock held by the replica that sends reminders.
+	remindersLockID = 7_326_448_176
+	// remindersInterval is how often the reminder job looks for tasks that are about to be overdue.
+	remindersInterval = time.Minute
+	// remindersBatchSize is the maximum number of reminders sent on each run of the job.
+	remindersBatchSize = 100
+	// defaultReminderLeadTime is how long before the due date reminders are sent to users that didn't choose.
+	defaultReminderLeadTime = 24 * time.Hour
+	// reminderMaxAttempts is the number of failed attempts after which a reminder is given up.
+	reminderMaxAttempts = 5
+)
+
+// TaskReminder defines a schema of the reminders that were sent or attempted. A reminder is unique per task,
+// recipient and due date, so every assignee is reminded once, and again only if the due date changes.
+type TaskReminder struct {
+	bun.BaseModel `bun:"table:task_reminders"`
+
+	Id        int64     `bun:",pk,autoincrement"`
+	TaskId    int32     `bun:",notnull"`
+	Recipient string    `bun:",notnull"`
+	DueAt     time.Time `bun:",notnull"`
+	Attempts  int32     `bun:",notnull"`
+	LastError string    `bun:",nullzero"`
+	// NextAttemptAt is when a failed reminder is retried, it is unset once the reminder was sent or given up.
+	NextAttemptAt time.Time `bun:",nullzero,default:current_timestamp"`
+	SentAt        time.Time `bun:",nullzero"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// due reports whether the reminder has to be attempted at now.
+func (reminder TaskReminder) due(now time.Time) bool {
+	return !reminder.NextAttemptAt.IsZero() && !reminder.NextAttemptAt.After(now)
+}
+
+// settle records the result of an attempt of the reminder made at now.
+// A failed reminder is retried after remindersInterval, doubled on every failure, until it fails
+// reminderMaxAttempts times and is given up.
+func (reminder *TaskReminder) settle(err error, now time.Time) {
+	reminder.Attempts++
+	if err == nil {
+		reminder.SentAt = now
+		reminder.NextAttemptAt = time.Time{}
+		return
+	}
+	reminder.LastError = err.Error()
+	if reminder.Attempts >= reminderMaxAttempts {
+		reminder.NextAttemptAt = time.Time{}
+		return
+	}
+	reminder.NextAttemptAt = now.Add(remindersInterval << (reminder.Attempts - 1))
+}
+
+// reminderRecipients returns the preferences of the assignee of each task, in the order of the tasks.
+// Assignees without preferences get the default ones.
+func reminderRecipients(tasks []*Task, preferences []NotificationPreferences) []NotificationPreferences {
+	preferencesBySubject := make(map[string]NotificationPreferences, len(preferences))
+	for _, userPreferences := range preferences {
+		preferencesBySubject[userPreferences.Subject] = userPreferences
+	}
+	recipients := make([]NotificationPreferences, len(tasks))
+	for i, task := range tasks {
+		recipient, ok := preferencesBySubject[task.Assignee]
+		if !ok {
+			recipient = defaultNotificationPreferences(task.Assignee)
+		}
+		recipients[i] = recipient
+	}
+	return recipients
+}
+
+// reminderMessage returns the message of a reminder about the task.
+func reminderMessage(task *Task) string {
+	return fmt.Sprintf("Task #%d %q is due at %s.", task.Id, task.Title, formatTimestamp(task.DueAt))
+}
+
+// runReminders reminds the assignees of the tasks that are about to be overdue until ctx is done.
+// Every replica runs the job, but only the one holding the reminders lock does the work on each tick.
+func runReminders(ctx context.Context, db *bun.DB, configured notifiers) {
+	ticker := time.NewTicker(remindersInterval)
+	defer ticker.Stop()
+	for {
+		if err := sendReminders(ctx, db, configured, time.Now()); err != nil {
+			zap.L().Error("Failed to send reminders", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendReminders reminds the assignees of open tasks that are due within their reminder lead time and
+// weren't reminded about the current due date yet, unless the reminder is waiting for a retry or was given up.
+// It does nothing if another replica holds the reminders lock.
+func sendReminders(ctx context.Context, db *bun.DB, configured notifiers, now time.Time) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire a connection: %w", err)
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(?)", remindersLockID).Scan(&locked); err != nil {
+		return fmt.Errorf("failed to acquire reminders lock: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock(?)", remindersLockID)
+	}()
+
+	var tasks []*Task
+	if err = db.NewSelect().
+		Model(&tasks).
+		Join("LEFT JOIN task_notification_preferences AS preferences ON preferences.subject = task.assignee").
+		Where("? IS NOT NULL", bun.Ident("task.assignee")).
+		Where("? NOT IN (?)", bun.Ident("task.status"), bun.In([]TaskStatus{TaskStatusDone, TaskStatusCancelled})).
+		Where("? > ?", bun.Ident("task.due_at"), now).
+		Where("? <= ?::timestamptz + make_interval(mins => coalesce(nullif(preferences.remind_before_minutes, 0), ?))",
+			bun.Ident("task.due_at"), now, int(defaultReminderLeadTime.Minutes())).
+		// users without preferences get the default ones, users with no channels are never notified
+		Where("(preferences.subject IS NULL OR cardinality(preferences.channels) > 0)").
+		Where("NOT EXISTS (SELECT 1 FROM task_reminders AS reminder WHERE reminder.task_id = task.id "+
+			"AND reminder.recipient = task.assignee AND reminder.due_at = task.due_at "+
+			"AND (reminder.next_attempt_at IS NULL OR reminder.next_attempt_at > ?))", now).
+		Order("task.due_at").
+		Limit(remindersBatchSize).
+		Scan(ctx); err != nil {
+		return fmt.Errorf("failed to fetch tasks to remind about: %w", err)
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	subjects := make([]string, len(tasks))
+	for i, task := range tasks {
+		subjects[i] = task.Assignee
+	}
+	var preferences []NotificationPreferences
+	if err = db.NewSelect().
+		Model(&preferences).
+		Where("? IN (?)", bun.Ident("subject"), bun.In(subjects)).
+		Scan(ctx); err != nil {
+		return fmt.Errorf("failed to fetch notification preferences: %w", err)
+	}
+
+	for i, recipient := range reminderRecipients(tasks, preferences) {
+		// a reminder that fails doesn't stop the others, it is retried later
+		if err = sendReminder(ctx, db, configured, recipient, tasks[i], now); err != nil {
+			zap.L().Error("Failed to send a reminder", zap.Int32("task_id", tasks[i].Id), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// sendReminder records the reminder about the task and sends it to the recipient, if it is due at now.
+// The result is recorded, so that a failed reminder is retried until it is given up.
+func sendReminder(ctx context.Context, db *bun.DB, configured notifiers, recipient NotificationPreferences,
+	task *Task, now time.Time) error {
+	// the first attempt is due right away, the default timestamp of the database would be later than now
+	reminder := TaskReminder{TaskId: task.Id, Recipient: recipient.Subject, DueAt: task.DueAt, NextAttemptAt: now}
+	if _, err := db.NewInsert().
+		Model(&reminder).
+		On("CONFLICT (task_id, recipient, due_at) DO NOTHING").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record a reminder: %w", err)
+	}
+	if err := db.NewSelect().
+		Model(&reminder).
+		Where("? = ?", bun.Ident("task_id"), reminder.TaskId).
+		Where("? = ?", bun.Ident("recipient"), reminder.Recipient).
+		Where("? = ?", bun.Ident("due_at"), reminder.DueAt).
+		Scan(ctx); err != nil {
+		return fmt.Errorf("failed to fetch a reminder: %w", err)
+	}
+	if !reminder.due(now) {
+		return nil
+	}
+
+	sendErr := configured.send(ctx, recipient, task, reminderMessage(task))
+	reminder.settle(sendErr, time.Now())
+	if sendErr != nil && reminder.NextAttemptAt.IsZero() {
+		zap.L().Error("Gave up a reminder", zap.Int32("task_id", task.Id), zap.String("recipient", recipient.Subject),
+			zap.Int32("attempts", reminder.Attempts), zap.Error(sendErr))
+	}
+	if _, err := db.NewUpdate().
+		Model(&reminder).
+		Column("attempts", "last_error", "next_attempt_at", "sent_at").
+		WherePK().
+		Exec(context.WithoutCancel(ctx)); err != nil {
+		return fmt.Errorf("failed to record the result of a reminder: %w", err)
+	}
+	return sendErr
+}
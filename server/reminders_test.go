@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTaskReminderDue(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		reminder TaskReminder
+		want     bool
+	}{
+		{name: "new", reminder: TaskReminder{NextAttemptAt: now}, want: true},
+		{name: "retry is due", reminder: TaskReminder{Attempts: 1, NextAttemptAt: now.Add(-time.Second)}, want: true},
+		{name: "waiting for a retry", reminder: TaskReminder{Attempts: 1, NextAttemptAt: now.Add(time.Second)}},
+		{name: "sent", reminder: TaskReminder{Attempts: 1, SentAt: now.Add(-time.Hour)}},
+		{name: "given up", reminder: TaskReminder{Attempts: reminderMaxAttempts, LastError: "failed"}},
+	}
+	for _, test := range tests {
+		if got := test.reminder.due(now); got != test.want {
+			t.Errorf("%s: due = %t, want %t", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTaskReminderSettle(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	errSend := errors.New("failed to notify by email")
+	tests := []struct {
+		name         string
+		attempts     int32
+		err          error
+		wantAttempts int32
+		wantSent     bool
+		wantNext     time.Time
+	}{
+		{name: "sent", wantAttempts: 1, wantSent: true},
+		{name: "sent after failures", attempts: 2, wantAttempts: 3, wantSent: true},
+		{name: "first failure", err: errSend, wantAttempts: 1, wantNext: now.Add(remindersInterval)},
+		{name: "backoff", attempts: 2, err: errSend, wantAttempts: 3, wantNext: now.Add(4 * remindersInterval)},
+		{name: "given up", attempts: reminderMaxAttempts - 1, err: errSend, wantAttempts: reminderMaxAttempts},
+	}
+	for _, test := range tests {
+		reminder := TaskReminder{Id: 1, Attempts: test.attempts, NextAttemptAt: now}
+		reminder.settle(test.err, now)
+		if reminder.Attempts != test.wantAttempts {
+			t.Errorf("%s: attempts = %d, want %d", test.name, reminder.Attempts, test.wantAttempts)
+		}
+		if sent := !reminder.SentAt.IsZero(); sent != test.wantSent {
+			t.Errorf("%s: sent = %t, want %t", test.name, sent, test.wantSent)
+		}
+		if !reminder.NextAttemptAt.Equal(test.wantNext) {
+			t.Errorf("%s: next attempt at %s, want %s", test.name, reminder.NextAttemptAt, test.wantNext)
+		}
+		if test.err != nil && reminder.LastError != test.err.Error() {
+			t.Errorf("%s: last error = %q, want %q", test.name, reminder.LastError, test.err.Error())
+		}
+		if reminder.due(now.Add(time.Hour)) != (test.err != nil && test.wantAttempts < reminderMaxAttempts) {
+			t.Errorf("%s: due an hour later = %t", test.name, reminder.due(now.Add(time.Hour)))
+		}
+	}
+}
+
+func TestReminderRecipients(t *testing.T) {
+	tasks := []*Task{{Id: 1, Assignee: "alice"}, {Id: 2, Assignee: "bob"}, {Id: 3, Assignee: "alice"}}
+	preferences := []NotificationPreferences{
+		{Subject: "alice", Channels: []string{NotificationChannelEmail}, Email: "alice@clinic.example"},
+		{Subject: "carol", Channels: []string{NotificationChannelSMS}},
+	}
+
+	recipients := reminderRecipients(tasks, preferences)
+	if len(recipients) != len(tasks) {
+		t.Fatalf("got %d recipients, want %d", len(recipients), len(tasks))
+	}
+	for i, want := range []NotificationPreferences{preferences[0], defaultNotificationPreferences("bob"), preferences[0]} {
+		if recipients[i].Subject != want.Subject || recipients[i].Email != want.Email ||
+			len(recipients[i].Channels) != 1 || recipients[i].Channels[0] != want.Channels[0] {
+			t.Errorf("recipient of task %d = %+v, want %+v", tasks[i].Id, recipients[i], want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// schedulerLockID is the key of the Postgres advisory lock held by the replica that runs the scheduler.
+	schedulerLockID = 7_326_448_175
+	// schedulerInterval is how often the scheduler looks for templates with due occurrences.
+	schedulerInterval = time.Minute
+	// schedulerActor is recorded in the task history as the creator of the tasks created from templates.
+	schedulerActor = "scheduler"
+)
+
+// runScheduler creates tasks from the templates with due occurrences until ctx is done.
+// Every replica runs the scheduler, but only the one holding the scheduler lock does the work on each tick.
+// The created tasks are validated with validate, as the ones created by CreateTask.
+func runScheduler(ctx context.Context, db *bun.DB, validate *validator.Validate) {
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+	for {
+		if err := scheduleTemplates(ctx, db, validate, time.Now()); err != nil {
+			zap.L().Error("Failed to create tasks from templates", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scheduleTemplates creates the tasks of all templates with occurrences that are due by now.
+// It does nothing if another replica holds the scheduler lock.
+func scheduleTemplates(ctx context.Context, db *bun.DB, validate *validator.Validate, now time.Time) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire a connection: %w", err)
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(?)", schedulerLockID).Scan(&locked); err != nil {
+		return fmt.Errorf("failed to acquire scheduler lock: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock(?)", schedulerLockID)
+	}()
+
+	var ids []int32
+	if err = db.NewSelect().
+		Model((*TaskTemplate)(nil)).
+		Column("id").
+		Where("? <= ?", bun.Ident("next_run_at"), now).
+		Order("id").
+		Scan(ctx, &ids); err != nil {
+		return fmt.Errorf("failed to fetch due templates: %w", err)
+	}
+	for _, id := range ids {
+		// a template that fails doesn't stop the others, it is retried on the next tick
+		if err = scheduleTemplate(ctx, db, validate, id, now); err != nil {
+			zap.L().Error("Failed to create a task from a template", zap.Int32("template_id", id), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// scheduleTemplate creates the task of the latest due occurrence of a template and moves it to the next occurrence.
+// Occurrences that were missed while no scheduler was running are skipped.
+// Tasks are unique per template and occurrence, so an occurrence never gets two tasks.
+// An occurrence whose task is not valid is skipped, since it would fail the same way on every retry.
+// The patient isn't verified with the patients service, there is no caller whose token could be forwarded.
+func scheduleTemplate(ctx context.Context, db *bun.DB, validate *validator.Validate, id int32, now time.Time) error {
+	return db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		template := new(TaskTemplate)
+		err := tx.NewSelect().
+			Model(template).
+			Where("? = ?", bun.Ident("id"), id).
+			Where("? <= ?", bun.Ident("next_run_at"), now).
+			For("UPDATE SKIP LOCKED").
+			Scan(ctx)
+		if err != nil {
+			// the template was deleted, moved or is being scheduled right now
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		rec, err := parseRecurrence(template.Recurrence)
+		if err != nil {
+			return err
+		}
+
+		occurrence, next := rec.latest(template.StartsAt, now)
+		task := template.newTask(occurrence)
+		if err = validate.Struct(task); err != nil {
+			zap.L().Error("Skipped an occurrence of a template whose task is not valid", zap.Int32("template_id", id),
+				zap.Time("occurrence", occurrence), zap.Error(err))
+		} else if err = insertTask(ctx, tx, &task, schedulerActor); status.Code(err) == codes.FailedPrecondition {
+			zap.L().Warn("Skipped an occurrence of a template whose patient is archived", zap.Int32("template_id", id),
+				zap.Time("occurrence", occurrence))
+		} else if err != nil {
+			return err
+		}
+		template.NextRunAt = next
+		_, err = tx.NewUpdate().Model(template).Column("next_run_at").WherePK().Exec(ctx)
+		return err
+	})
+}
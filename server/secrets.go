@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	ms "github.com/TekClinic/MicroService-Lib"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// secretsKeySize is the size of the AES-256 key that encrypts the stored secrets.
+const secretsKeySize = 32
+
+var errSealedSecretTooShort = errors.New("sealed secret is too short")
+
+// secretBox encrypts secrets that have to be stored to be used later, such as the signing secrets of webhooks.
+// Secrets are sealed with AES-256-GCM, a random nonce is prepended to each sealed secret.
+type secretBox struct {
+	aead cipher.AEAD
+}
+
+// newSecretBox creates a secretBox with a key of secretsKeySize bytes.
+func newSecretBox(key []byte) (*secretBox, error) {
+	if len(key) != secretsKeySize {
+		return nil, fmt.Errorf("key has to be %d bytes long, got %d", secretsKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &secretBox{aead: aead}, nil
+}
+
+// createWebhookSecretsBox creates the secretBox of webhook secrets with the base64 encoded key of envWebhookSecretsKey.
+// It returns nil if the key is not configured, in which case webhooks can't be created or dispatched.
+func createWebhookSecretsBox() (*secretBox, error) {
+	encodedKey := ms.GetOptionalEnv(envWebhookSecretsKey, "")
+	if encodedKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", envWebhookSecretsKey, err)
+	}
+	box, err := newSecretBox(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", envWebhookSecretsKey, err)
+	}
+	return box, nil
+}
+
+// seal encrypts the secret.
+func (box *secretBox) seal(secret string) ([]byte, error) {
+	nonce := make([]byte, box.aead.NonceSize(), box.aead.NonceSize()+len(secret)+box.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate a nonce: %w", err)
+	}
+	return box.aead.Seal(nonce, nonce, []byte(secret), nil), nil
+}
+
+// open decrypts a secret sealed with the same key.
+func (box *secretBox) open(sealed []byte) (string, error) {
+	if len(sealed) < box.aead.NonceSize() {
+		return "", errSealedSecretTooShort
+	}
+	nonce, ciphertext := sealed[:box.aead.NonceSize()], sealed[box.aead.NonceSize():]
+	secret, err := box.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt a secret: %w", err)
+	}
+	return string(secret), nil
+}
+
+// sealWebhookSecrets encrypts the secrets of webhooks that were created before secrets were encrypted,
+// and removes their plaintext.
+func sealWebhookSecrets(ctx context.Context, db *bun.DB, box *secretBox) error {
+	return db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		var webhooks []struct {
+			Id     int32
+			Secret string
+		}
+		if err := tx.NewSelect().
+			Table("task_webhooks").
+			Column("id", "secret").
+			Where("? IS NOT NULL", bun.Ident("secret")).
+			For("UPDATE").
+			Scan(ctx, &webhooks); err != nil {
+			return fmt.Errorf("failed to fetch plaintext webhook secrets: %w", err)
+		}
+		for _, webhook := range webhooks {
+			sealed, err := box.seal(webhook.Secret)
+			if err != nil {
+				return err
+			}
+			if _, err = tx.NewUpdate().
+				Table("task_webhooks").
+				Set("encrypted_secret = ?", sealed).
+				Set("secret = NULL").
+				Where("? = ?", bun.Ident("id"), webhook.Id).
+				Exec(ctx); err != nil {
+				return fmt.Errorf("failed to encrypt a webhook secret: %w", err)
+			}
+		}
+		if len(webhooks) > 0 {
+			zap.L().Info("Encrypted plaintext webhook secrets", zap.Int("count", len(webhooks)))
+		}
+		return nil
+	})
+}
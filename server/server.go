@@ -6,6 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -26,6 +33,16 @@ type tasksServer struct {
 	ppb.UnimplementedTasksServiceServer
 	ms.BaseServiceServer
 	db *bun.DB
+	// events wakes up WatchTasks streams when task events are published
+	events *taskEventHub
+	// permissions maps the permissions checked by the handlers to the roles they are granted to
+	permissions permissionPolicy
+	// notifiers are the configured notification channels
+	notifiers notifiers
+	// patients verifies patient ids, nil if the patients service is not configured
+	patients *patientsClient
+	// webhookSecrets seals the signing secrets of webhooks, nil if envWebhookSecretsKey is not configured
+	webhookSecrets *secretBox
 	// use a single instance of Validate, it caches struct info
 	validate *validator.Validate
 }
@@ -35,58 +52,150 @@ const (
 	envDBUser     = "DB_USER"
 	envDBDatabase = "DB_DATABASE"
 	envDBPassword = "DB_PASSWORD"
+	// envPermissions optionally configures the permission policy, see parsePermissionPolicy.
+	envPermissions = "TASKS_PERMISSIONS"
+	// envEventsWebhookURL optionally configures a URL that task events are posted to, see webhookSink.
+	envEventsWebhookURL = "TASKS_EVENTS_WEBHOOK_URL"
+	// envSMTPAddress optionally configures the SMTP server (host:port) of email notifications.
+	envSMTPAddress = "TASKS_SMTP_ADDR"
+	// envSMTPFrom is the sender address of email notifications, required along with envSMTPAddress.
+	envSMTPFrom = "TASKS_SMTP_FROM"
+	// envSMTPUser and envSMTPPassword optionally authenticate to the SMTP server.
+	envSMTPUser     = "TASKS_SMTP_USER"
+	envSMTPPassword = "TASKS_SMTP_PASSWORD"
+	// envPatientsHost optionally configures the patients service, which patient ids are verified with.
+	envPatientsHost = "MS_PATIENTS_HOST"
+	// envSMSGatewayURL optionally configures the gateway of SMS notifications, see smsNotifier.
+	envSMSGatewayURL = "TASKS_SMS_GATEWAY_URL"
+	// envWebhookSecretsKey is the base64 encoded AES-256 key that seals the signing secrets of webhooks, see secretBox.
+	// Webhooks can't be created nor dispatched without it.
+	envWebhookSecretsKey = "TASKS_WEBHOOK_SECRETS_KEY"
 
 	applicationName = "tasks"
 
 	permissionDeniedMessage = "You don't have enough permission to access this resource"
+	// commentsSignature identifies page tokens of task comments.
+	commentsSignature = "comments"
+	// taskHistorySignature identifies page tokens of task history.
+	taskHistorySignature = "history"
+	// notificationsSignature identifies page tokens of notifications.
+	notificationsSignature = "notifications"
+	// webhookDeliveriesSignature identifies page tokens of webhook deliveries.
+	webhookDeliveriesSignature = "webhook_deliveries"
+	// taskVersionConflictMessage is returned when a task was changed since the version a client has seen.
+	taskVersionConflictMessage = "task was changed by someone else, fetch it again and retry"
 
 	maxPaginationLimit = 50
+	// watchPollInterval is how often WatchTasks checks for events it wasn't notified about,
+	// or that were held back by a running transaction.
+	watchPollInterval = 5 * time.Second
+	// watchMaxHoldBack is how long WatchTasks holds back committed events while an earlier transaction runs.
+	watchMaxHoldBack = 30 * time.Second
+	// shutdownTimeout is how long running calls are waited for when the server is stopped.
+	shutdownTimeout = 10 * time.Second
 )
 
 // GetTask returns a task that corresponds to the given id.
 // Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
-// Requires an admin role. If roles are not sufficient, codes.PermissionDenied is returned.
+// Requires the tasks:read permission, unless the caller is the assignee of the task.
+// If permissions are not sufficient, codes.PermissionDenied is returned.
+// Deleted tasks are returned only if include_deleted is set, with their deletion time.
 // If a task with a given id doesn't exist, codes.NotFound is returned.
+// The task is returned with its prerequisites and the ones that block it.
 func (server tasksServer) GetTask(ctx context.Context, req *ppb.GetTaskRequest) (
 	*ppb.GetTaskResponse, error) {
-	claims, err := server.VerifyToken(ctx, req.GetToken())
-	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, err.Error())
-	}
-	if !claims.HasRole("admin") {
-		return nil, status.Error(codes.PermissionDenied, permissionDeniedMessage)
+	claims := callerClaims(ctx)
+	canRead := server.permissions.allows(claims, permissionRead)
+	var subject string
+	var err error
+	if !canRead {
+		// without the read permission, users can only read the tasks assigned to them
+		if subject, err = callerSubject(ctx); err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
 	}
 
 	task := new(Task)
-	err = server.db.NewSelect().
+	query := server.db.NewSelect().
 		Model(task).
-		Where("? = ?", bun.Ident("id"), req.GetId()).
-		WhereAllWithDeleted().
-		Scan(ctx)
-	if err != nil {
+		Where("? = ?", bun.Ident("id"), req.GetId())
+	if req.GetIncludeDeleted() {
+		query = query.WhereAllWithDeleted()
+	}
+	if err = query.Scan(ctx); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Error(codes.NotFound, "task is not found")
 		}
 		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch a tasks by id: %w", err).Error())
 	}
+	if !canRead && task.Assignee != subject {
+		return nil, status.Error(codes.PermissionDenied, permissionDeniedMessage)
+	}
+	if err = loadTaskDetails(ctx, server.db, task); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err = loadDependencies(ctx, server.db, task); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch task dependencies: %w", err).Error())
+	}
 	return &ppb.GetTaskResponse{Task: task.toGRPC()}, nil
 }
 
+// GetTasks returns the tasks that correspond to the given ids, in the order of the ids.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:read permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// Up to the maximum pagination limit of ids can be requested at once, otherwise codes.InvalidArgument is returned.
+// Ids of tasks that don't exist are returned as missing ids instead of failing the whole request.
+func (server tasksServer) GetTasks(ctx context.Context, req *ppb.GetTasksRequest) (*ppb.GetTasksResponse, error) {
+	if len(req.GetIds()) > maxPaginationLimit {
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("maximum allowed number of ids is %d", maxPaginationLimit))
+	}
+	if len(req.GetIds()) == 0 {
+		return &ppb.GetTasksResponse{}, nil
+	}
+
+	var tasks []Task
+	err := server.db.NewSelect().
+		Model(&tasks).
+		Where("? IN (?)", bun.Ident("id"), bun.In(req.GetIds())).
+		Scan(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch tasks by ids: %w", err).Error())
+	}
+
+	tasksByID := make(map[int32]*Task, len(tasks))
+	pointers := make([]*Task, len(tasks))
+	for i := range tasks {
+		tasksByID[tasks[i].Id] = &tasks[i]
+		pointers[i] = &tasks[i]
+	}
+	if err = loadTaskDetails(ctx, server.db, pointers...); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	response := &ppb.GetTasksResponse{}
+	for _, id := range req.GetIds() {
+		if task, ok := tasksByID[id]; ok {
+			response.Tasks = append(response.Tasks, task.toGRPC())
+		} else {
+			response.MissingIds = append(response.MissingIds, id)
+		}
+	}
+	return response, nil
+}
+
 // GetTasksIDs returns a list of tasks' ids with given filters and pagination.
 // Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
-// Requires an admin role. If roles are not sufficient, codes.PermissionDenied is returned.
+// Requires the tasks:read permission. If permissions are not sufficient, codes.PermissionDenied is returned.
 // Offset value is used for pagination. Required be a non-negative value.
 // Limit value is used for pagination. Required to be a positive value.
+// Page token value is used for cursor pagination instead of offset. If there are more results,
+// a token of the next page is returned. Cursor pages don't skip or repeat tasks when tasks are added meanwhile.
+// Search value is optional. If set, only matching tasks are returned, ordered by relevance.
+// Tasks can be filtered by priority and due date, and overdue_only returns only unfinished tasks past their due date.
+// Filter value is optional and narrows down the results by patient, expertise, completion, creation time and assignee.
+// Results are ordered by order_by if set, then by search relevance, then by id.
 func (server tasksServer) GetTasksIDs(ctx context.Context,
 	req *ppb.GetTasksIDsRequest) (*ppb.GetTasksIDsResponse, error) {
-	claims, err := server.VerifyToken(ctx, req.GetToken())
-	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, err.Error())
-	}
-	if !claims.HasRole("admin") {
-		return nil, status.Error(codes.PermissionDenied, permissionDeniedMessage)
-	}
-
 	if req.GetOffset() < 0 {
 		return nil, status.Error(codes.InvalidArgument, "offset has to be a non-negative integer")
 	}
@@ -96,245 +205,1878 @@ func (server tasksServer) GetTasksIDs(ctx context.Context,
 	if req.GetLimit() > maxPaginationLimit {
 		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("maximum allowed limit values is %d", maxPaginationLimit))
 	}
+	if req.GetPageToken() != "" && req.GetOffset() != 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset can't be used together with page_token")
+	}
 
-	var ids []int32
 	baseQuery := server.db.NewSelect().Model((*Task)(nil)).Column("id")
 
-    // TODO: Implement search
-    /*
+	baseQuery, err := applyUrgencyFilter(baseQuery, req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	baseQuery, err = applyTaskFilter(baseQuery, req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sorting, err := taskSortingFor(req.GetOrderBy(), req.GetDescending(), req.GetSearch() != "")
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	if req.GetSearch() != "" {
 		// Postgres specific code. Use full-text search to search for tasks.
+		// Every search term is matched as a prefix, so typing part of a title is enough.
 		baseQuery = baseQuery.
 			TableExpr("replace(websearch_to_tsquery('simple', ?)::text || ' ',''' ',''':*') query", req.GetSearch()).
-			Where("text_searchable @@ query::tsquery", req.GetSearch()).
-			OrderExpr("ts_rank(text_searchable, query::tsquery) DESC")
+			Where("text_searchable @@ query::tsquery")
 	}
-    */
 
-	err = baseQuery.
-		Offset(int(req.GetOffset())).
-		Limit(int(req.GetLimit())).
-		Scan(ctx, &ids)
-	if err != nil {
-		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch tasks: %w", err).Error())
-	}
+	// count before the page is selected, the count covers all matching tasks
 	count, err := baseQuery.Count(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Errorf("failed to count tasks: %w", err).Error())
 	}
+	rows, nextPageToken, err := fetchTasksPage(ctx, baseQuery.Offset(int(req.GetOffset())), sorting,
+		int(req.GetLimit()), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
 
+	ids := make([]int32, len(rows))
+	for i, row := range rows {
+		ids[i] = row.Id
+	}
 	return &ppb.GetTasksIDsResponse{
-		Count:   int32(count),
-		Results: ids,
+		Count:         int32(count),
+		Results:       ids,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
 // CreateTask creates a task with the given specifications.
 // Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
-// Requires an admin role. If roles are not sufficient, codes.PermissionDenied is returned.
+// Requires the tasks:write permission. If permissions are not sufficient, codes.PermissionDenied is returned.
 // If some argument is missing or not valid, codes.InvalidArgument is returned.
+// Due date is optional and has to be in the future. Priority defaults to normal.
+// Patient id is required. If the patients service is configured and the patient doesn't exist,
+// codes.InvalidArgument is returned. If the patient is archived, codes.FailedPrecondition is returned.
+// The creation is recorded in the task history.
 func (server tasksServer) CreateTask(ctx context.Context,
 	req *ppb.CreateTaskRequest) (*ppb.CreateTaskResponse, error) {
-	claims, err := server.VerifyToken(ctx, req.GetToken())
+	dueAt, err := parseTimestamp(req.GetDueAt())
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, err.Error())
+		return nil, status.Error(codes.InvalidArgument, fmt.Errorf("failed to parse task due date: %w", err).Error())
 	}
-	if !claims.HasRole("admin") {
-		return nil, status.Error(codes.PermissionDenied, permissionDeniedMessage)
+	if !dueAt.IsZero() && dueAt.Before(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "task due date has to be in the future")
+	}
+	priority, err := taskPriorityFromGRPC(req.GetPriority())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if priority == 0 {
+		priority = TaskPriorityNormal
+	}
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 
 	task := Task{
-		Complete:       false,
-        Title:          req.GetTitle(),
-        Description:    req.GetDescription(),
-        Expertise:      req.GetExpertise(),
-        PatientId:      req.GetPatientId(),
+		Complete:    false,
+		Status:      TaskStatusOpen,
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Expertise:   req.GetExpertise(),
+		PatientId:   req.GetPatientId(),
+		SpecialNote: req.GetSpecialNote(),
+		DueAt:       dueAt,
+		Priority:    priority,
 	}
 	if err = server.validate.Struct(task); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if err = server.checkPatient(ctx, task.PatientId); err != nil {
+		return nil, err
+	}
 	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
-		// insert the task itself
-		if _, txErr := tx.NewInsert().Model(&task).Exec(ctx); txErr != nil {
-			return txErr
-		}
-		return nil
+		return insertTask(ctx, tx, &task, subject)
 	}); err != nil {
-		return nil, status.Error(codes.Internal, fmt.Errorf("failed to create a task: %w", err).Error())
+		return nil, err
 	}
 	return &ppb.CreateTaskResponse{Id: task.Id}, nil
 }
 
 // DeleteTask deletes a task with the given id.
+// The task is soft deleted, it can be restored with RestoreTask or permanently deleted with PurgeTask.
 // Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
-// Requires an admin role. If roles are not sufficient, codes.PermissionDenied is returned.
+// Requires the tasks:delete permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// Version of the task is required to be its current version. If the version is missing,
+// codes.InvalidArgument is returned. If the task was changed since that version, codes.Aborted is returned.
 // If a task with a given id doesn't exist, codes.NotFound is returned.
+// The deletion moves the task to the next version and is recorded in the task history.
 func (server tasksServer) DeleteTask(ctx context.Context, req *ppb.DeleteTaskRequest) (
 	*ppb.DeleteTaskResponse, error) {
-	claims, err := server.VerifyToken(ctx, req.GetToken())
+	if req.GetVersion() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "task version is required")
+	}
+	subject, err := callerSubject(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
-	if !claims.HasRole("admin") {
-		return nil, status.Error(codes.PermissionDenied, permissionDeniedMessage)
+
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		task, txErr := lockTask(ctx, tx, req.GetId(), taskScopeActive)
+		if txErr != nil {
+			return txErr
+		}
+		if task.Version != req.GetVersion() {
+			return status.Error(codes.Aborted, taskVersionConflictMessage)
+		}
+
+		// the version is moved on, so updates based on a version seen before the deletion fail after a restore
+		if _, txErr = tx.NewUpdate().
+			Model(task).
+			Set("deleted_at = current_timestamp").
+			Set("version = version + 1").
+			WherePK().
+			Exec(ctx); txErr != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to delete a task: %w", txErr).Error())
+		}
+		if txErr = publishTaskEvent(ctx, tx, task.Id, TaskEventDeleted); txErr != nil {
+			return txErr
+		}
+		after, txErr := reloadTask(ctx, tx, task.Id)
+		if txErr != nil {
+			return txErr
+		}
+		return recordTaskAudit(ctx, tx, TaskAuditActionDelete, subject, task, after)
+	}); err != nil {
+		return nil, err
 	}
+	return &ppb.DeleteTaskResponse{}, nil
+}
 
-	res, err := server.db.NewDelete().Model((*Task)(nil)).Where("id = ?", req.GetId()).Exec(ctx)
+// RestoreTask restores a deleted task with the given id.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:delete permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If a deleted task with a given id doesn't exist, codes.NotFound is returned.
+// The restoration is recorded in the task history.
+func (server tasksServer) RestoreTask(ctx context.Context, req *ppb.RestoreTaskRequest) (
+	*ppb.RestoreTaskResponse, error) {
+	subject, err := callerSubject(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Internal, fmt.Errorf("failed to delete a task: %w", err).Error())
+		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
-	// if db supports affected rows count and no rows were affected, return not found
-	rows, err := res.RowsAffected()
-	if err == nil && rows == 0 {
-		return nil, status.Error(codes.NotFound, "task is not found")
+
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		task, txErr := lockTask(ctx, tx, req.GetId(), taskScopeDeleted)
+		if txErr != nil {
+			return txErr
+		}
+
+		if _, txErr = tx.NewUpdate().
+			Model(task).
+			Set("deleted_at = NULL").
+			Set("version = version + 1").
+			WherePK().
+			WhereDeleted().
+			Exec(ctx); txErr != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to restore a task: %w", txErr).Error())
+		}
+		if txErr = publishTaskEvent(ctx, tx, task.Id, TaskEventRestored); txErr != nil {
+			return txErr
+		}
+		after, txErr := reloadTask(ctx, tx, task.Id)
+		if txErr != nil {
+			return txErr
+		}
+		return recordTaskAudit(ctx, tx, TaskAuditActionRestore, subject, task, after)
+	}); err != nil {
+		return nil, err
 	}
-	return &ppb.DeleteTaskResponse{}, nil
+	return &ppb.RestoreTaskResponse{}, nil
 }
 
-// UpdateTask updates a task with the given id and data.
+// PurgeTask permanently deletes a task with the given id along with all its related data.
+// Both deleted and not deleted tasks can be purged. Purged tasks can't be restored, but their history is kept.
 // Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
-// Requires an admin role. If roles are not sufficient, codes.PermissionDenied is returned.
-// If some argument is missing or not valid, codes.InvalidArgument is returned.
+// Requires the tasks:delete permission. If permissions are not sufficient, codes.PermissionDenied is returned.
 // If a task with a given id doesn't exist, codes.NotFound is returned.
-func (server tasksServer) UpdateTask(ctx context.Context, req *ppb.UpdateTaskRequest) (
-	*ppb.UpdateTaskResponse, error) {
-	claims, err := server.VerifyToken(ctx, req.GetToken())
+func (server tasksServer) PurgeTask(ctx context.Context, req *ppb.PurgeTaskRequest) (
+	*ppb.PurgeTaskResponse, error) {
+	subject, err := callerSubject(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
-	if !claims.HasRole("admin") {
-		return nil, status.Error(codes.PermissionDenied, permissionDeniedMessage)
+
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		task, txErr := lockTask(ctx, tx, req.GetId(), taskScopeAll)
+		if txErr != nil {
+			return txErr
+		}
+
+		// the event copies the task, so it is published before the task is gone
+		if txErr = publishTaskEvent(ctx, tx, task.Id, TaskEventDeleted); txErr != nil {
+			return txErr
+		}
+		if _, txErr = tx.NewDelete().
+			Model(task).
+			WherePK().
+			WhereAllWithDeleted().
+			ForceDelete().
+			Exec(ctx); txErr != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to purge a task: %w", txErr).Error())
+		}
+		return recordTaskAudit(ctx, tx, TaskAuditActionPurge, subject, task, nil)
+	}); err != nil {
+		return nil, err
 	}
+	return &ppb.PurgeTaskResponse{}, nil
+}
 
+// UpdateTask updates a task with the given id and data.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:write permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// Update mask value is optional. If set, only the listed fields are updated and validated,
+// otherwise all the fields are replaced.
+// If some argument is missing or not valid, codes.InvalidArgument is returned.
+// If a task with a given id doesn't exist, codes.NotFound is returned.
+// The assignee is not changed, use AssignTask and UnassignTask for that.
+// The status is not changed, use TransitionTask for that. For backward compatibility, changing
+// the complete flag moves the task to done or back to open, and codes.FailedPrecondition is returned
+// if that move is not allowed or some prerequisites of the task are not finished.
+// If the priority is unspecified, the current priority is kept. Without an update mask, the due date and
+// the special note are kept as well if they are not set, only a mask that lists them can clear them.
+// If the due date is changed to a time in the past, codes.InvalidArgument is returned.
+// If the patient is changed, the patients service is configured and the patient doesn't exist,
+// codes.InvalidArgument is returned. If the new patient is archived, codes.FailedPrecondition is returned.
+// Version of the task is required to be its current version. If the version is missing,
+// codes.InvalidArgument is returned. If the task was changed since that version, codes.Aborted is returned.
+// The new version of the task is returned. The update is recorded in the task history.
+func (server tasksServer) UpdateTask(ctx context.Context, req *ppb.UpdateTaskRequest) (
+	*ppb.UpdateTaskResponse, error) {
 	task, err := taskFromGRPC(req.GetTask())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	if err = server.validate.Struct(task); err != nil {
+	update, err := taskUpdateFromMask(req.GetUpdateMask().GetPaths())
+	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if len(update.fields) > 0 {
+		if err = server.validate.StructPartial(task, update.fields...); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
 
 	if task.Id == 0 {
 		return nil, status.Error(codes.InvalidArgument, "Task ID is required")
 	}
+	if task.Version == 0 {
+		return nil, status.Error(codes.InvalidArgument, "task version is required")
+	}
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
 
+	if slices.Contains(update.fields, "PatientId") {
+		if err = server.checkUpdatedPatient(ctx, task); err != nil {
+			return nil, err
+		}
+	}
 
 	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		current, txErr := lockTask(ctx, tx, task.Id, taskScopeActive)
+		if txErr != nil {
+			return txErr
+		}
+		if current.Version != task.Version {
+			return status.Error(codes.Aborted, taskVersionConflictMessage)
+		}
+
+		if txErr = update.merge(&task, current, time.Now()); txErr != nil {
+			return status.Error(codes.InvalidArgument, txErr.Error())
+		}
+		if slices.Contains(update.fields, "PatientId") && task.PatientId != current.PatientId {
+			if txErr = checkPatientNotArchived(ctx, tx, task.PatientId); txErr != nil {
+				return txErr
+			}
+		}
+
+		// clients that don't know about statuses complete and reopen tasks by flipping the complete flag
+		if update.complete && task.Complete != current.Complete {
+			target := TaskStatusOpen
+			if task.Complete {
+				target = TaskStatusDone
+			}
+			if txErr = transitionTask(ctx, tx, current, target, subject, TaskAuditActionUpdate); txErr != nil {
+				return txErr
+			}
+		}
+		// the transition above may have already moved the task to the next version
+		task.Version = current.Version
+		if len(update.columns) == 0 {
+			return nil
+		}
+		// the transition is recorded in the task history on its own, so the changes are taken after it
+		before, txErr := reloadTask(ctx, tx, task.Id)
+		if txErr != nil {
+			return txErr
+		}
+
 		// update the task
-		res, txErr := tx.NewUpdate().
+		task.Version++
+		if _, txErr = tx.NewUpdate().
 			Model(&task).
-			ExcludeColumn("created_at", "deleted_at").
+			Column(append(update.columns, "version")...).
 			WherePK().
-			Exec(ctx)
-		if txErr != nil {
+			Exec(ctx); txErr != nil {
 			return status.Error(codes.Internal, fmt.Errorf("failed to update a task: %w", txErr).Error())
 		}
+		if txErr = publishTaskEvent(ctx, tx, task.Id, TaskEventUpdated); txErr != nil {
+			return txErr
+		}
+		after, txErr := reloadTask(ctx, tx, task.Id)
+		if txErr != nil {
+			return txErr
+		}
+		return recordTaskAudit(ctx, tx, TaskAuditActionUpdate, subject, before, after)
+	}); err != nil {
+		return nil, err
+	}
+	return &ppb.UpdateTaskResponse{Id: task.Id, Version: task.Version}, nil
+}
 
-		// if db supports affected rows count and no rows were affected, return not found
-		rows, rowsErr := res.RowsAffected()
-		if rowsErr == nil && rows == 0 {
+// checkUpdatedPatient verifies the patient of a task that UpdateTask changes, see checkPatient.
+// The patient is verified only when it changes, so tasks of deleted patients can still be updated.
+// It is verified before the task is locked, so the lock isn't held while the patients service is called,
+// the version check makes sure that the task, and so its patient, didn't change since.
+func (server tasksServer) checkUpdatedPatient(ctx context.Context, task Task) error {
+	current := new(Task)
+	if err := server.db.NewSelect().
+		Model(current).
+		Column("patient_id", "version").
+		Where("? = ?", bun.Ident("id"), task.Id).
+		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
 			return status.Error(codes.NotFound, "task is not found")
 		}
-
+		return status.Error(codes.Internal, fmt.Errorf("failed to fetch a task by id: %w", err).Error())
+	}
+	if current.Version != task.Version {
+		return status.Error(codes.Aborted, taskVersionConflictMessage)
+	}
+	if task.PatientId == current.PatientId {
 		return nil
-	}); err != nil {
-		return nil, err
 	}
-	return &ppb.UpdateTaskResponse{Id: task.Id}, nil
+	return server.checkPatient(ctx, task.PatientId)
 }
 
-// GetTasksByPatient returns a list of tasks for a given patient id.
+// GetTasksByPatient returns a list of tasks for a given patient id with pagination.
 // Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
-// Requires an admin role. If roles are not sufficient, codes.PermissionDenied is returned.
+// Requires the tasks:read permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// Limit value is used for pagination. Required to be a non-negative value, defaults to the maximum allowed limit.
+// If there are more results, a token of the next page is returned, to be sent back as page token.
+// Completed (done or cancelled) tasks are returned unless include_completed is set to false.
+// Results are ordered by order_by if set, then by id.
 // If no tasks are found for the patient, an empty list is returned.
 func (server tasksServer) GetTasksByPatient(ctx context.Context, req *ppb.GetTasksByPatientRequest) (*ppb.GetTasksByPatientResponse, error) {
-    claims, err := server.VerifyToken(ctx, req.GetToken())
-    if err != nil {
-        return nil, status.Error(codes.Unauthenticated, err.Error())
-    }
-    if !claims.HasRole("admin") {
-        return nil, status.Error(codes.PermissionDenied, permissionDeniedMessage)
-    }
-
-    var tasks []Task
-    err = server.db.NewSelect().
-        Model(&tasks).
-        Where("patient_id = ?", req.GetPatientId()).
-        Scan(ctx)
-    if err != nil {
-        return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch tasks: %w", err).Error())
-    }
-
-    grpcTasks := make([]*ppb.Task, len(tasks))
-    for i, t := range tasks {
-        grpcTasks[i] = t.toGRPC()
-    }
-
-    return &ppb.GetTasksByPatientResponse{
-        Tasks: grpcTasks,
-    }, nil
-}
-
-// createTasksServer initializes a tasksServer with all the necessary fields.
-func createTasksServer() (*tasksServer, error) {
-	base, err := ms.CreateBaseServiceServer()
-	if err != nil {
-		return nil, err
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit has to be a non-negative integer")
 	}
-	addr, err := ms.GetRequiredEnv(envDBAddress)
-	if err != nil {
-		return nil, err
+	if req.GetLimit() > maxPaginationLimit {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("maximum allowed limit values is %d", maxPaginationLimit))
 	}
-	user, err := ms.GetRequiredEnv(envDBUser)
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = maxPaginationLimit
+	}
+	sorting, err := taskSortingFor(req.GetOrderBy(), req.GetDescending(), false)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	password, err := ms.GetRequiredEnv(envDBPassword)
+
+	query := server.db.NewSelect().
+		Model((*Task)(nil)).
+		ColumnExpr("?TableColumns").
+		Where("? = ?", bun.Ident("task.patient_id"), req.GetPatientId())
+	if req.IncludeCompleted != nil && !req.GetIncludeCompleted() {
+		query = query.Where("? NOT IN (?)", bun.Ident("task.status"),
+			bun.In([]TaskStatus{TaskStatusDone, TaskStatusCancelled}))
+	}
+
+	// count before the page is selected, the count covers all matching tasks
+	count, err := query.Count(ctx)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to count tasks: %w", err).Error())
 	}
-	database, err := ms.GetRequiredEnv(envDBDatabase)
+	rows, nextPageToken, err := fetchTasksPage(ctx, query, sorting, limit, req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	connector := pgdriver.NewConnector(
-		pgdriver.WithNetwork("tcp"),
-		pgdriver.WithAddr(addr),
-		pgdriver.WithUser(user),
-		pgdriver.WithPassword(password),
-		pgdriver.WithDatabase(database),
-		pgdriver.WithApplicationName(applicationName),
-		pgdriver.WithInsecure(!ms.HasSecureConnection()),
-	)
-	db := bun.NewDB(sql.OpenDB(connector), pgdialect.New())
-	db.AddQueryHook(ms.GetDBQueryHook())
-	return &tasksServer{
-		BaseServiceServer: base,
-		db:                db,
-		validate:          validator.New(validator.WithRequiredStructEnabled())}, nil
+
+	pointers := make([]*Task, len(rows))
+	for i := range rows {
+		pointers[i] = &rows[i].Task
+	}
+	if err = loadTaskDetails(ctx, server.db, pointers...); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	grpcTasks := make([]*ppb.Task, len(rows))
+	for i, row := range rows {
+		grpcTasks[i] = row.Task.toGRPC()
+	}
+
+	return &ppb.GetTasksByPatientResponse{
+		Tasks:         grpcTasks,
+		NextPageToken: nextPageToken,
+		Count:         int32(count),
+	}, nil
 }
 
-func main() {
-	service, err := createTasksServer()
+// AssignTask assigns a task with the given id to a user.
+// If no assignee is given, the task is assigned to the caller, which lets users claim open tasks.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Claiming a task requires the tasks:read permission. Assigning a task to another user or taking over a task
+// assigned to another user requires the tasks:assign permission.
+// If permissions are not sufficient, codes.PermissionDenied is returned.
+// If a task with a given id doesn't exist, codes.NotFound is returned.
+func (server tasksServer) AssignTask(ctx context.Context, req *ppb.AssignTaskRequest) (
+	*ppb.AssignTaskResponse, error) {
+	claims := callerClaims(ctx)
+	subject, err := callerSubject(ctx)
 	if err != nil {
-		zap.L().Fatal("Failed to create a task server", zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	err = createSchemaIfNotExists(context.Background(), service.db)
-	if err != nil {
-		zap.L().Fatal("Failed to create a schema", zap.Error(err))
+	assignee := req.GetAssignee()
+	if assignee == "" {
+		assignee = subject
+	}
+	canAssign := server.permissions.allows(claims, permissionAssign)
+	// claiming a task gives access to it, so only the users that can read all the tasks may claim them
+	canClaim := assignee == subject && server.permissions.allows(claims, permissionRead)
+	if !canAssign && !canClaim {
+		return nil, status.Error(codes.PermissionDenied, permissionDeniedMessage)
 	}
 
-	listen, err := net.Listen("tcp", ":"+service.GetPort())
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		task, txErr := lockTask(ctx, tx, req.GetId(), taskScopeActive)
+		if txErr != nil {
+			return txErr
+		}
+		if task.Assignee != "" && task.Assignee != assignee && !canAssign {
+			return status.Error(codes.PermissionDenied, "task is already assigned to another user")
+		}
+
+		if _, txErr = tx.NewUpdate().
+			Model(task).
+			Set("assignee = ?", assignee).
+			Set("assigned_at = current_timestamp").
+			Set("version = version + 1").
+			WherePK().
+			Exec(ctx); txErr != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to assign a task: %w", txErr).Error())
+		}
+		if txErr = publishTaskEvent(ctx, tx, task.Id, TaskEventAssigned); txErr != nil {
+			return txErr
+		}
+		after, txErr := reloadTask(ctx, tx, task.Id)
+		if txErr != nil {
+			return txErr
+		}
+		return recordTaskAudit(ctx, tx, TaskAuditActionUpdate, subject, task, after)
+	}); err != nil {
+		return nil, err
+	}
+	return &ppb.AssignTaskResponse{}, nil
+}
+
+// UnassignTask removes the assignee of a task with the given id.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires to be the assignee of the task or the tasks:assign permission. If permissions are not sufficient,
+// codes.PermissionDenied is returned.
+// If a task with a given id doesn't exist, codes.NotFound is returned.
+func (server tasksServer) UnassignTask(ctx context.Context, req *ppb.UnassignTaskRequest) (
+	*ppb.UnassignTaskResponse, error) {
+	claims := callerClaims(ctx)
+	subject, err := callerSubject(ctx)
 	if err != nil {
-		zap.L().Fatal("Failed to listen", zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	srv := grpc.NewServer(ms.GetGRPCServerOptions()...)
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		task, txErr := lockTask(ctx, tx, req.GetId(), taskScopeActive)
+		if txErr != nil {
+			return txErr
+		}
+		if task.Assignee != subject && !server.permissions.allows(claims, permissionAssign) {
+			return status.Error(codes.PermissionDenied, permissionDeniedMessage)
+		}
+
+		if _, txErr = tx.NewUpdate().
+			Model(task).
+			Set("assignee = NULL").
+			Set("assigned_at = NULL").
+			Set("version = version + 1").
+			WherePK().
+			Exec(ctx); txErr != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to unassign a task: %w", txErr).Error())
+		}
+		if txErr = publishTaskEvent(ctx, tx, task.Id, TaskEventUpdated); txErr != nil {
+			return txErr
+		}
+		after, txErr := reloadTask(ctx, tx, task.Id)
+		if txErr != nil {
+			return txErr
+		}
+		return recordTaskAudit(ctx, tx, TaskAuditActionUpdate, subject, task, after)
+	}); err != nil {
+		return nil, err
+	}
+	return &ppb.UnassignTaskResponse{}, nil
+}
+
+// GetMyTasksIDs returns a list of ids of the tasks assigned to the caller with pagination.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Offset value is used for pagination. Required be a non-negative value.
+// Limit value is used for pagination. Required to be a positive value.
+// Finished (done or cancelled) tasks are returned only if include_complete is set.
+func (server tasksServer) GetMyTasksIDs(ctx context.Context, req *ppb.GetMyTasksIDsRequest) (
+	*ppb.GetMyTasksIDsResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset has to be a non-negative integer")
+	}
+	if req.GetLimit() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit has to be a positive integer")
+	}
+	if req.GetLimit() > maxPaginationLimit {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("maximum allowed limit values is %d", maxPaginationLimit))
+	}
+
+	var ids []int32
+	baseQuery := server.db.NewSelect().
+		Model((*Task)(nil)).
+		Column("id").
+		Where("? = ?", bun.Ident("assignee"), subject).
+		Order("id")
+	if !req.GetIncludeComplete() {
+		baseQuery = baseQuery.Where("? NOT IN (?)", bun.Ident("status"),
+			bun.In([]TaskStatus{TaskStatusDone, TaskStatusCancelled}))
+	}
+
+	err = baseQuery.
+		Offset(int(req.GetOffset())).
+		Limit(int(req.GetLimit())).
+		Scan(ctx, &ids)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch tasks: %w", err).Error())
+	}
+	count, err := baseQuery.Count(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to count tasks: %w", err).Error())
+	}
+
+	return &ppb.GetMyTasksIDsResponse{
+		Count:   int32(count),
+		Results: ids,
+	}, nil
+}
+
+// TransitionTask moves a task with the given id to a new status and records who did it.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires to be the assignee of the task or the tasks:write permission, so assignees can complete their own tasks.
+// If permissions are not sufficient, codes.PermissionDenied is returned.
+// If the status is missing or not valid, codes.InvalidArgument is returned.
+// If a task with a given id doesn't exist, codes.NotFound is returned.
+// If the task can't move from its current status to the requested one, codes.FailedPrecondition is returned.
+// Tasks can't start or be completed while some of their prerequisites are not finished,
+// and tasks of archived patients can't be reopened.
+func (server tasksServer) TransitionTask(ctx context.Context, req *ppb.TransitionTaskRequest) (
+	*ppb.TransitionTaskResponse, error) {
+	claims := callerClaims(ctx)
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	target, err := taskStatusFromGRPC(req.GetStatus())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var task *Task
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		var txErr error
+		if task, txErr = lockTask(ctx, tx, req.GetId(), taskScopeActive); txErr != nil {
+			return txErr
+		}
+		if task.Assignee != subject && !server.permissions.allows(claims, permissionWrite) {
+			return status.Error(codes.PermissionDenied, permissionDeniedMessage)
+		}
+		if txErr = transitionTask(ctx, tx, task, target, subject, TaskAuditActionUpdate); txErr != nil {
+			return txErr
+		}
+		if txErr = loadTaskDetails(ctx, tx, task); txErr != nil {
+			return status.Error(codes.Internal, txErr.Error())
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &ppb.TransitionTaskResponse{Task: task.toGRPC()}, nil
+}
+
+// GetTaskHistory returns the audit trail of a task with the given id, newest changes first, with pagination.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:read permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// Limit value is used for pagination. Required to be a non-negative value, defaults to the maximum allowed limit.
+// If there are more results, a token of the next page is returned, to be sent back as page token.
+// History of deleted and purged tasks is returned as well. If the task has no history, an empty list is returned.
+func (server tasksServer) GetTaskHistory(ctx context.Context, req *ppb.GetTaskHistoryRequest) (
+	*ppb.GetTaskHistoryResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit has to be a non-negative integer")
+	}
+	if req.GetLimit() > maxPaginationLimit {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("maximum allowed limit values is %d", maxPaginationLimit))
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = maxPaginationLimit
+	}
+
+	var entries []TaskAuditEntry
+	query := server.db.NewSelect().
+		Model(&entries).
+		Where("? = ?", bun.Ident("task_id"), req.GetTaskId()).
+		OrderExpr("? DESC", bun.Ident("id")).
+		// fetch one extra entry to know whether there is a next page
+		Limit(limit + 1)
+	if req.GetPageToken() != "" {
+		cursor, cursorErr := decodePageToken(taskHistorySignature, 1, req.GetPageToken())
+		if cursorErr != nil {
+			return nil, status.Error(codes.InvalidArgument, cursorErr.Error())
+		}
+		query = query.Where("? < ?::bigint", bun.Ident("id"), cursor.SortKeys[0])
+	}
+	err := query.Scan(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch task history: %w", err).Error())
+	}
+
+	var nextPageToken string
+	if len(entries) > limit {
+		entries = entries[:limit]
+		nextPageToken, err = encodePageToken(taskHistorySignature,
+			[]string{strconv.FormatInt(entries[len(entries)-1].Id, 10)})
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Errorf("failed to create a page token: %w", err).Error())
+		}
+	}
+
+	grpcEntries := make([]*ppb.TaskHistoryEntry, len(entries))
+	for i, entry := range entries {
+		if grpcEntries[i], err = entry.toGRPC(); err != nil {
+			return nil, status.Error(codes.Internal, fmt.Errorf("failed to convert task history: %w", err).Error())
+		}
+	}
+	return &ppb.GetTaskHistoryResponse{
+		Entries:       grpcEntries,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// AddChecklistItem adds a checklist item to a task with the given id.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:write permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If some argument is missing or not valid, codes.InvalidArgument is returned.
+// Position value is optional. If not set, the item is added after all the other items.
+// If a task with a given id doesn't exist, codes.NotFound is returned.
+// A completed task is reopened, since the new item is not done yet.
+func (server tasksServer) AddChecklistItem(ctx context.Context, req *ppb.AddChecklistItemRequest) (
+	*ppb.AddChecklistItemResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	item := ChecklistItem{
+		TaskId:   req.GetTaskId(),
+		Title:    req.GetTitle(),
+		Position: req.GetPosition(),
+	}
+	if err = server.validate.Struct(item); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		task, txErr := lockTask(ctx, tx, req.GetTaskId(), taskScopeActive)
+		if txErr != nil {
+			return txErr
+		}
+		if item.Position == 0 {
+			if txErr = tx.NewSelect().
+				Model((*ChecklistItem)(nil)).
+				ColumnExpr("coalesce(max(?), 0) + 1", bun.Ident("position")).
+				Where("? = ?", bun.Ident("task_id"), task.Id).
+				Scan(ctx, &item.Position); txErr != nil {
+				return status.Error(codes.Internal, fmt.Errorf("failed to fetch checklist items: %w", txErr).Error())
+			}
+		}
+		if _, txErr = tx.NewInsert().Model(&item).Exec(ctx); txErr != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to add a checklist item: %w", txErr).Error())
+		}
+		if txErr = publishTaskEvent(ctx, tx, task.Id, TaskEventUpdated); txErr != nil {
+			return txErr
+		}
+		return syncTaskWithChecklist(ctx, tx, task, subject)
+	}); err != nil {
+		return nil, err
+	}
+	return &ppb.AddChecklistItemResponse{Item: item.toGRPC()}, nil
+}
+
+// UpdateChecklistItem updates a checklist item with the given id and data.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires to be the assignee of the task or the tasks:write permission, so assignees can check off their steps.
+// If permissions are not sufficient, codes.PermissionDenied is returned.
+// Update mask value is optional. If set, only the listed fields are updated, otherwise all the fields are replaced.
+// If some argument is missing or not valid, codes.InvalidArgument is returned.
+// If a checklist item with a given id doesn't exist, codes.NotFound is returned.
+// The task is completed once all its items are done, and reopened once one of them is not.
+func (server tasksServer) UpdateChecklistItem(ctx context.Context, req *ppb.UpdateChecklistItemRequest) (
+	*ppb.UpdateChecklistItemResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	columns, err := checklistItemUpdateFromMask(req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	item := ChecklistItem{
+		Id:       req.GetItem().GetId(),
+		Title:    req.GetItem().GetTitle(),
+		Done:     req.GetItem().GetDone(),
+		Position: req.GetItem().GetPosition(),
+	}
+	if slices.Contains(columns, "title") {
+		if err = server.validate.StructPartial(item, "Title"); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		current := new(ChecklistItem)
+		txErr := tx.NewSelect().
+			Model(current).
+			Where("? = ?", bun.Ident("id"), item.Id).
+			Scan(ctx)
+		if txErr != nil {
+			if errors.Is(txErr, sql.ErrNoRows) {
+				return status.Error(codes.NotFound, "checklist item is not found")
+			}
+			return status.Error(codes.Internal, fmt.Errorf("failed to fetch a checklist item by id: %w", txErr).Error())
+		}
+		task, txErr := lockTask(ctx, tx, current.TaskId, taskScopeActive)
+		if txErr != nil {
+			return txErr
+		}
+		if task.Assignee != subject && !server.permissions.allows(callerClaims(ctx), permissionWrite) {
+			return status.Error(codes.PermissionDenied, permissionDeniedMessage)
+		}
+
+		if txErr = tx.NewUpdate().
+			Model(&item).
+			Column(columns...).
+			WherePK().
+			Returning("*").
+			Scan(ctx); txErr != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to update a checklist item: %w", txErr).Error())
+		}
+		if txErr = publishTaskEvent(ctx, tx, task.Id, TaskEventUpdated); txErr != nil {
+			return txErr
+		}
+		return syncTaskWithChecklist(ctx, tx, task, subject)
+	}); err != nil {
+		return nil, err
+	}
+	return &ppb.UpdateChecklistItemResponse{Item: item.toGRPC()}, nil
+}
+
+// DeleteChecklistItem deletes a checklist item with the given id.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:write permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If a checklist item with a given id doesn't exist, codes.NotFound is returned.
+// The task is completed if all its remaining items are done.
+func (server tasksServer) DeleteChecklistItem(ctx context.Context, req *ppb.DeleteChecklistItemRequest) (
+	*ppb.DeleteChecklistItemResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		item := new(ChecklistItem)
+		txErr := tx.NewSelect().
+			Model(item).
+			Where("? = ?", bun.Ident("id"), req.GetId()).
+			Scan(ctx)
+		if txErr != nil {
+			if errors.Is(txErr, sql.ErrNoRows) {
+				return status.Error(codes.NotFound, "checklist item is not found")
+			}
+			return status.Error(codes.Internal, fmt.Errorf("failed to fetch a checklist item by id: %w", txErr).Error())
+		}
+		task, txErr := lockTask(ctx, tx, item.TaskId, taskScopeActive)
+		if txErr != nil {
+			return txErr
+		}
+		if _, txErr = tx.NewDelete().Model(item).WherePK().Exec(ctx); txErr != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to delete a checklist item: %w", txErr).Error())
+		}
+		if txErr = publishTaskEvent(ctx, tx, task.Id, TaskEventUpdated); txErr != nil {
+			return txErr
+		}
+		return syncTaskWithChecklist(ctx, tx, task, subject)
+	}); err != nil {
+		return nil, err
+	}
+	return &ppb.DeleteChecklistItemResponse{}, nil
+}
+
+// AddDependency makes a task with the given id depend on another task, so it can't start until the other is finished.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:write permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If either of the tasks doesn't exist, codes.NotFound is returned.
+// If the dependency would create a cycle, codes.InvalidArgument is returned.
+// Adding an existing dependency does nothing.
+func (server tasksServer) AddDependency(ctx context.Context, req *ppb.AddDependencyRequest) (
+	*ppb.AddDependencyResponse, error) {
+	if err := server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if _, txErr := lockTask(ctx, tx, req.GetTaskId(), taskScopeActive); txErr != nil {
+			return txErr
+		}
+		exists, txErr := tx.NewSelect().
+			Model((*Task)(nil)).
+			Where("? = ?", bun.Ident("id"), req.GetDependsOnId()).
+			Exists(ctx)
+		if txErr != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to fetch a task by id: %w", txErr).Error())
+		}
+		if !exists {
+			return status.Error(codes.NotFound, "prerequisite task is not found")
+		}
+		return addDependency(ctx, tx, req.GetTaskId(), req.GetDependsOnId())
+	}); err != nil {
+		return nil, err
+	}
+	return &ppb.AddDependencyResponse{}, nil
+}
+
+// RemoveDependency removes a dependency of a task with the given id on another task.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:write permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If the dependency doesn't exist, codes.NotFound is returned.
+func (server tasksServer) RemoveDependency(ctx context.Context, req *ppb.RemoveDependencyRequest) (
+	*ppb.RemoveDependencyResponse, error) {
+	res, err := server.db.NewDelete().
+		Model((*TaskDependency)(nil)).
+		Where("? = ?", bun.Ident("task_id"), req.GetTaskId()).
+		Where("? = ?", bun.Ident("depends_on_id"), req.GetDependsOnId()).
+		Exec(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to remove a task dependency: %w", err).Error())
+	}
+	// if db supports affected rows count and no rows were affected, return not found
+	rows, err := res.RowsAffected()
+	if err == nil && rows == 0 {
+		return nil, status.Error(codes.NotFound, "task dependency is not found")
+	}
+	return &ppb.RemoveDependencyResponse{}, nil
+}
+
+// CreateTaskTemplate creates a template of a recurring task.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:write permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If some argument is missing or not valid, codes.InvalidArgument is returned.
+// Start time is optional and defaults to now, the first task is created at the start time.
+// Priority of the created tasks defaults to normal.
+// Patient id is required. If the patients service is configured and the patient doesn't exist,
+// codes.InvalidArgument is returned. If the patient is archived, codes.FailedPrecondition is returned,
+// and occurrences of templates whose patient is archived later are skipped.
+func (server tasksServer) CreateTaskTemplate(ctx context.Context, req *ppb.CreateTaskTemplateRequest) (
+	*ppb.CreateTaskTemplateResponse, error) {
+	startsAt, err := parseTimestamp(req.GetTemplate().GetStartsAt())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Errorf("failed to parse template start time: %w", err).Error())
+	}
+	if startsAt.IsZero() {
+		startsAt = time.Now()
+	}
+	if _, err = parseRecurrence(req.GetTemplate().GetRecurrence()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	priority, err := taskPriorityFromGRPC(req.GetTemplate().GetPriority())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if priority == 0 {
+		priority = TaskPriorityNormal
+	}
+
+	template := TaskTemplate{
+		Title:       req.GetTemplate().GetTitle(),
+		Description: req.GetTemplate().GetDescription(),
+		Expertise:   req.GetTemplate().GetExpertise(),
+		PatientId:   req.GetTemplate().GetPatientId(),
+		SpecialNote: req.GetTemplate().GetSpecialNote(),
+		Priority:    priority,
+		Recurrence:  req.GetTemplate().GetRecurrence(),
+		StartsAt:    startsAt,
+		DueInHours:  req.GetTemplate().GetDueInHours(),
+		NextRunAt:   startsAt,
+	}
+	if err = server.validate.Struct(template); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = server.checkPatient(ctx, template.PatientId); err != nil {
+		return nil, err
+	}
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if txErr := checkPatientNotArchived(ctx, tx, template.PatientId); txErr != nil {
+			return txErr
+		}
+		if _, txErr := tx.NewInsert().Model(&template).Exec(ctx); txErr != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to create a task template: %w", txErr).Error())
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &ppb.CreateTaskTemplateResponse{Id: template.Id}, nil
+}
+
+// GetTaskTemplate returns a task template that corresponds to the given id.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:read permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If a task template with a given id doesn't exist, codes.NotFound is returned.
+func (server tasksServer) GetTaskTemplate(ctx context.Context, req *ppb.GetTaskTemplateRequest) (
+	*ppb.GetTaskTemplateResponse, error) {
+	template := new(TaskTemplate)
+	err := server.db.NewSelect().
+		Model(template).
+		Where("? = ?", bun.Ident("id"), req.GetId()).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "task template is not found")
+		}
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch a task template by id: %w", err).Error())
+	}
+	return &ppb.GetTaskTemplateResponse{Template: template.toGRPC()}, nil
+}
+
+// GetTaskTemplates returns a list of task templates with pagination.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:read permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// Patient id value is optional. If set, only the templates of the patient are returned.
+// Offset value is used for pagination. Required be a non-negative value.
+// Limit value is used for pagination. Required to be a positive value.
+func (server tasksServer) GetTaskTemplates(ctx context.Context, req *ppb.GetTaskTemplatesRequest) (
+	*ppb.GetTaskTemplatesResponse, error) {
+	if req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset has to be a non-negative integer")
+	}
+	if req.GetLimit() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit has to be a positive integer")
+	}
+	if req.GetLimit() > maxPaginationLimit {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("maximum allowed limit values is %d", maxPaginationLimit))
+	}
+
+	var templates []TaskTemplate
+	query := server.db.NewSelect().
+		Model(&templates).
+		Order("id")
+	if req.GetPatientId() != 0 {
+		query = query.Where("? = ?", bun.Ident("patient_id"), req.GetPatientId())
+	}
+	count, err := query.
+		Offset(int(req.GetOffset())).
+		Limit(int(req.GetLimit())).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch task templates: %w", err).Error())
+	}
+
+	grpcTemplates := make([]*ppb.TaskTemplate, len(templates))
+	for i, template := range templates {
+		grpcTemplates[i] = template.toGRPC()
+	}
+	return &ppb.GetTaskTemplatesResponse{
+		Count:     int32(count),
+		Templates: grpcTemplates,
+	}, nil
+}
+
+// DeleteTaskTemplate deletes a task template with the given id, so no more tasks are created from it.
+// Tasks that were already created from the template are kept.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:write permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If a task template with a given id doesn't exist, codes.NotFound is returned.
+func (server tasksServer) DeleteTaskTemplate(ctx context.Context, req *ppb.DeleteTaskTemplateRequest) (
+	*ppb.DeleteTaskTemplateResponse, error) {
+	res, err := server.db.NewDelete().
+		Model((*TaskTemplate)(nil)).
+		Where("? = ?", bun.Ident("id"), req.GetId()).
+		Exec(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to delete a task template: %w", err).Error())
+	}
+	// if db supports affected rows count and no rows were affected, return not found
+	rows, err := res.RowsAffected()
+	if err == nil && rows == 0 {
+		return nil, status.Error(codes.NotFound, "task template is not found")
+	}
+	return &ppb.DeleteTaskTemplateResponse{}, nil
+}
+
+// AddComment adds a comment on behalf of the caller to a task with the given id.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:read permission, unless the caller is the assignee of the task.
+// If permissions are not sufficient, codes.PermissionDenied is returned.
+// If the body is missing or too long, codes.InvalidArgument is returned.
+// If a task with a given id doesn't exist, codes.NotFound is returned.
+func (server tasksServer) AddComment(ctx context.Context, req *ppb.AddCommentRequest) (
+	*ppb.AddCommentResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	comment := TaskComment{
+		TaskId: req.GetTaskId(),
+		Author: subject,
+		Body:   req.GetBody(),
+	}
+	if err = server.validate.Struct(comment); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err = server.fetchAccessibleTask(ctx, req.GetTaskId()); err != nil {
+		return nil, err
+	}
+
+	if _, err = server.db.NewInsert().Model(&comment).Exec(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to add a comment: %w", err).Error())
+	}
+	return &ppb.AddCommentResponse{Comment: comment.toGRPC()}, nil
+}
+
+// ListComments returns the comments of a task with the given id, oldest first, with pagination.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:read permission, unless the caller is the assignee of the task.
+// If permissions are not sufficient, codes.PermissionDenied is returned.
+// Limit value is used for pagination. Required to be a non-negative value, defaults to the maximum allowed limit.
+// If there are more results, a token of the next page is returned, to be sent back as page token.
+// If a task with a given id doesn't exist, codes.NotFound is returned.
+func (server tasksServer) ListComments(ctx context.Context, req *ppb.ListCommentsRequest) (
+	*ppb.ListCommentsResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit has to be a non-negative integer")
+	}
+	if req.GetLimit() > maxPaginationLimit {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("maximum allowed limit values is %d", maxPaginationLimit))
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = maxPaginationLimit
+	}
+	if _, err := server.fetchAccessibleTask(ctx, req.GetTaskId()); err != nil {
+		return nil, err
+	}
+
+	var comments []TaskComment
+	query := server.db.NewSelect().
+		Model(&comments).
+		Where("? = ?", bun.Ident("task_id"), req.GetTaskId())
+	// count before the page is selected, the count covers all comments of the task
+	count, err := query.Count(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to count comments: %w", err).Error())
+	}
+	if req.GetPageToken() != "" {
+		cursor, cursorErr := decodePageToken(commentsSignature, 1, req.GetPageToken())
+		if cursorErr != nil {
+			return nil, status.Error(codes.InvalidArgument, cursorErr.Error())
+		}
+		query = query.Where("? > ?::integer", bun.Ident("id"), cursor.SortKeys[0])
+	}
+	// fetch one extra comment to know whether there is a next page
+	if err = query.Order("id").Limit(limit + 1).Scan(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch comments: %w", err).Error())
+	}
+
+	var nextPageToken string
+	if len(comments) > limit {
+		comments = comments[:limit]
+		nextPageToken, err = encodePageToken(commentsSignature,
+			[]string{strconv.Itoa(int(comments[len(comments)-1].Id))})
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Errorf("failed to create a page token: %w", err).Error())
+		}
+	}
+
+	grpcComments := make([]*ppb.Comment, len(comments))
+	for i, comment := range comments {
+		grpcComments[i] = comment.toGRPC()
+	}
+	return &ppb.ListCommentsResponse{
+		Comments:      grpcComments,
+		NextPageToken: nextPageToken,
+		Count:         int32(count),
+	}, nil
+}
+
+// EditComment changes the body of a comment with the given id.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires to be the author of the comment. If not, codes.PermissionDenied is returned.
+// If the body is missing or too long, codes.InvalidArgument is returned.
+// If a comment with a given id doesn't exist, codes.NotFound is returned.
+func (server tasksServer) EditComment(ctx context.Context, req *ppb.EditCommentRequest) (
+	*ppb.EditCommentResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	comment := TaskComment{
+		Id:       req.GetId(),
+		Body:     req.GetBody(),
+		EditedAt: time.Now(),
+	}
+	if err = server.validate.StructPartial(comment, "Body"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = server.db.NewUpdate().
+		Model(&comment).
+		Column("body", "edited_at").
+		WherePK().
+		Where("? = ?", bun.Ident("author"), subject).
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.Internal, fmt.Errorf("failed to edit a comment: %w", err).Error())
+		}
+		// either the comment doesn't exist or it was written by someone else
+		return nil, server.commentNotEditable(ctx, req.GetId())
+	}
+	return &ppb.EditCommentResponse{Comment: comment.toGRPC()}, nil
+}
+
+// DeleteComment deletes a comment with the given id.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires to be the author of the comment or the tasks:delete permission. If permissions are not sufficient,
+// codes.PermissionDenied is returned.
+// If a comment with a given id doesn't exist, codes.NotFound is returned.
+func (server tasksServer) DeleteComment(ctx context.Context, req *ppb.DeleteCommentRequest) (
+	*ppb.DeleteCommentResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	query := server.db.NewDelete().
+		Model((*TaskComment)(nil)).
+		Where("? = ?", bun.Ident("id"), req.GetId())
+	if !server.permissions.allows(callerClaims(ctx), permissionDelete) {
+		query = query.Where("? = ?", bun.Ident("author"), subject)
+	}
+	res, err := query.Exec(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to delete a comment: %w", err).Error())
+	}
+	// if db supports affected rows count and no rows were affected,
+	// either the comment doesn't exist or it was written by someone else
+	rows, err := res.RowsAffected()
+	if err == nil && rows == 0 {
+		return nil, server.commentNotEditable(ctx, req.GetId())
+	}
+	return &ppb.DeleteCommentResponse{}, nil
+}
+
+// commentNotEditable returns the error of a comment that the caller failed to change:
+// codes.NotFound if it doesn't exist and codes.PermissionDenied otherwise.
+func (server tasksServer) commentNotEditable(ctx context.Context, id int32) error {
+	exists, err := server.db.NewSelect().
+		Model((*TaskComment)(nil)).
+		Where("? = ?", bun.Ident("id"), id).
+		Exists(ctx)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to fetch a comment by id: %w", err).Error())
+	}
+	if !exists {
+		return status.Error(codes.NotFound, "comment is not found")
+	}
+	return status.Error(codes.PermissionDenied, permissionDeniedMessage)
+}
+
+// WatchTasks streams the events of the tasks that match the filter as they happen.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:read permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// Filter fields are optional, unset fields match all the tasks.
+// If resume_after_id is set, the events that came after the event with that id are streamed first,
+// so a client that reconnects doesn't miss any. If that event was already removed, codes.OutOfRange is returned.
+// Otherwise, only new events are streamed.
+// Events are streamed once the transactions that published them and all the earlier ones have ended,
+// so a transaction that commits late can't be skipped. Any transaction in the database counts, so events that
+// a long-running transaction holds back for longer than watchMaxHoldBack are streamed ahead of it, and the events
+// of the transaction follow once it commits, out of order. Resuming after an event that was streamed ahead
+// skips the events of the transactions that were still running.
+// The stream is open until the client cancels it.
+func (server tasksServer) WatchTasks(req *ppb.WatchTasksRequest, stream ppb.TasksService_WatchTasksServer) error {
+	ctx := stream.Context()
+	// subscribe before the first fetch, so events announced in between are not missed
+	wake, unsubscribe := server.events.subscribe()
+	defer unsubscribe()
+
+	var cursor taskEventCursor
+	var err error
+	if req.GetResumeAfterId() != 0 {
+		if cursor, err = taskEventCursorAt(ctx, server.db, req.GetResumeAfterId()); err != nil {
+			return err
+		}
+	} else if cursor, err = currentTaskEventCursor(ctx, server.db); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to fetch task events: %w", err).Error())
+	}
+	watch := newTaskEventWatch(cursor)
+
+	// announcements can be lost while the listener reconnects, and the events of a transaction are held back
+	// while an earlier transaction runs, so events are also checked periodically
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		if err = server.sendTaskEvents(ctx, req, watch, stream); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendTaskEvents sends the events that came after the watch and match the filter of the request to the stream,
+// first the ones whose transactions and all the earlier ones have ended, then the ones held back for too long.
+func (server tasksServer) sendTaskEvents(ctx context.Context, req *ppb.WatchTasksRequest, watch *taskEventWatch,
+	stream ppb.TasksService_WatchTasksServer) error {
+	for {
+		var events []TaskEvent
+		query := selectTaskEventsAfter(server.db.NewSelect().Model(&events), watch.cursor).
+			Limit(maxPaginationLimit)
+		if err := filterTaskEvents(query, req).Scan(ctx); err != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to fetch task events: %w", err).Error())
+		}
+		for _, event := range events {
+			if !watch.advance(event) {
+				continue
+			}
+			if err := stream.Send(event.toGRPC()); err != nil {
+				return err
+			}
+		}
+		if len(events) < maxPaginationLimit {
+			break
+		}
+	}
+
+	for {
+		var events []TaskEvent
+		query := selectHeldBackTaskEvents(server.db.NewSelect().Model(&events), watch.cursor, watchMaxHoldBack).
+			Limit(maxPaginationLimit)
+		if ids := watch.aheadIds(); len(ids) > 0 {
+			query = query.Where("? NOT IN (?)", bun.Ident("id"), bun.In(ids))
+		}
+		if err := filterTaskEvents(query, req).Scan(ctx); err != nil {
+			return status.Error(codes.Internal, fmt.Errorf("failed to fetch task events: %w", err).Error())
+		}
+		for _, event := range events {
+			if err := stream.Send(event.toGRPC()); err != nil {
+				return err
+			}
+			watch.sendAhead(event)
+		}
+		if len(events) < maxPaginationLimit {
+			return nil
+		}
+	}
+}
+
+// filterTaskEvents narrows down the query to the events that match the filter of the request.
+func filterTaskEvents(query *bun.SelectQuery, req *ppb.WatchTasksRequest) *bun.SelectQuery {
+	if req.GetPatientId() != 0 {
+		query = query.Where("? = ?", bun.Ident("patient_id"), req.GetPatientId())
+	}
+	if req.GetAssignee() != "" {
+		query = query.Where("? = ?", bun.Ident("assignee"), req.GetAssignee())
+	}
+	if req.GetExpertise() != "" {
+		query = query.Where("? = ?", bun.Ident("expertise"), req.GetExpertise())
+	}
+	return query
+}
+
+// CreateWebhook subscribes a URL to task events on behalf of the caller.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:webhooks permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If the URL, some event type or the secret is not valid, codes.InvalidArgument is returned.
+// The URL has to resolve to public addresses only, see publicAddress, otherwise codes.InvalidArgument is returned.
+// If the key of webhook secrets is not configured, codes.FailedPrecondition is returned.
+// Event types are optional, the webhook is called for all the events if none are given.
+func (server tasksServer) CreateWebhook(ctx context.Context, req *ppb.CreateWebhookRequest) (
+	*ppb.CreateWebhookResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	eventTypes := make([]string, len(req.GetEventTypes()))
+	for i, eventType := range req.GetEventTypes() {
+		if eventTypes[i], err = taskEventTypeFromGRPC(eventType); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	webhook := Webhook{
+		Url:        req.GetUrl(),
+		EventTypes: eventTypes,
+		Secret:     req.GetSecret(),
+		CreatedBy:  subject,
+	}
+	if err = server.validate.Struct(webhook); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = checkWebhookURL(ctx, net.DefaultResolver, webhook.Url); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if server.webhookSecrets == nil {
+		return nil, status.Error(codes.FailedPrecondition, envWebhookSecretsKey+" is not configured")
+	}
+	if webhook.EncryptedSecret, err = server.webhookSecrets.seal(webhook.Secret); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if _, err = server.db.NewInsert().Model(&webhook).Exec(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to create a webhook: %w", err).Error())
+	}
+	return &ppb.CreateWebhookResponse{Id: webhook.Id}, nil
+}
+
+// GetWebhooks returns all the webhooks, without their secrets.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:webhooks permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+func (server tasksServer) GetWebhooks(ctx context.Context, _ *ppb.GetWebhooksRequest) (
+	*ppb.GetWebhooksResponse, error) {
+	var webhooks []Webhook
+	if err := server.db.NewSelect().
+		Model(&webhooks).
+		Order("id").
+		Scan(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch webhooks: %w", err).Error())
+	}
+
+	grpcWebhooks := make([]*ppb.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		grpcWebhooks[i] = webhook.toGRPC()
+	}
+	return &ppb.GetWebhooksResponse{Webhooks: grpcWebhooks}, nil
+}
+
+// DeleteWebhook deletes a webhook with the given id along with its deliveries, so it isn't called anymore.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:webhooks permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If a webhook with a given id doesn't exist, codes.NotFound is returned.
+func (server tasksServer) DeleteWebhook(ctx context.Context, req *ppb.DeleteWebhookRequest) (
+	*ppb.DeleteWebhookResponse, error) {
+	res, err := server.db.NewDelete().
+		Model((*Webhook)(nil)).
+		Where("? = ?", bun.Ident("id"), req.GetId()).
+		Exec(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to delete a webhook: %w", err).Error())
+	}
+	// if db supports affected rows count and no rows were affected, return not found
+	rows, err := res.RowsAffected()
+	if err == nil && rows == 0 {
+		return nil, status.Error(codes.NotFound, "webhook is not found")
+	}
+	return &ppb.DeleteWebhookResponse{}, nil
+}
+
+// ListWebhookDeliveries returns the deliveries of a webhook with the given id, newest first.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:webhooks permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If the state is set, only the deliveries in that state are returned, the dead state lists the dead letters.
+// If the limit, the state or the page token is not valid, codes.InvalidArgument is returned.
+// If a webhook with a given id doesn't exist, codes.NotFound is returned.
+func (server tasksServer) ListWebhookDeliveries(ctx context.Context, req *ppb.ListWebhookDeliveriesRequest) (
+	*ppb.ListWebhookDeliveriesResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit has to be a non-negative integer")
+	}
+	if req.GetLimit() > maxPaginationLimit {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("maximum allowed limit values is %d", maxPaginationLimit))
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = maxPaginationLimit
+	}
+	state, err := webhookDeliveryStateFromGRPC(req.GetState())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	exists, err := server.db.NewSelect().
+		Model((*Webhook)(nil)).
+		Where("? = ?", bun.Ident("id"), req.GetWebhookId()).
+		Exists(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch a webhook by id: %w", err).Error())
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "webhook is not found")
+	}
+
+	var deliveries []WebhookDelivery
+	query := server.db.NewSelect().
+		Model(&deliveries).
+		Where("? = ?", bun.Ident("webhook_id"), req.GetWebhookId())
+	if state != "" {
+		query = query.Where("? = ?", bun.Ident("state"), state)
+	}
+	if req.GetPageToken() != "" {
+		cursor, cursorErr := decodePageToken(webhookDeliveriesSignature, 1, req.GetPageToken())
+		if cursorErr != nil {
+			return nil, status.Error(codes.InvalidArgument, cursorErr.Error())
+		}
+		query = query.Where("? < ?::bigint", bun.Ident("id"), cursor.SortKeys[0])
+	}
+	// fetch one extra delivery to know whether there is a next page
+	if err = query.OrderExpr("id DESC").Limit(limit + 1).Scan(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch webhook deliveries: %w", err).Error())
+	}
+
+	var nextPageToken string
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+		nextPageToken, err = encodePageToken(webhookDeliveriesSignature,
+			[]string{strconv.FormatInt(deliveries[len(deliveries)-1].Id, 10)})
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Errorf("failed to create a page token: %w", err).Error())
+		}
+	}
+
+	grpcDeliveries := make([]*ppb.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		grpcDeliveries[i] = delivery.toGRPC()
+	}
+	return &ppb.ListWebhookDeliveriesResponse{
+		Deliveries:    grpcDeliveries,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// GetNotificationPreferences returns the notification preferences of the caller.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Callers that never set their preferences get the default ones.
+func (server tasksServer) GetNotificationPreferences(ctx context.Context, _ *ppb.GetNotificationPreferencesRequest) (
+	*ppb.GetNotificationPreferencesResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	preferences := NotificationPreferences{Subject: subject}
+	err = server.db.NewSelect().
+		Model(&preferences).
+		WherePK().
+		Scan(ctx)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.Internal,
+				fmt.Errorf("failed to fetch notification preferences: %w", err).Error())
+		}
+		preferences = defaultNotificationPreferences(subject)
+	}
+	return &ppb.GetNotificationPreferencesResponse{Preferences: preferences.toGRPC()}, nil
+}
+
+// UpdateNotificationPreferences replaces the notification preferences of the caller.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// If some channel, the email, the phone or the reminder lead time is not valid, codes.InvalidArgument is returned.
+// The email channel requires an email and the SMS channel requires a phone.
+// If a channel is not configured on the server, codes.FailedPrecondition is returned.
+func (server tasksServer) UpdateNotificationPreferences(ctx context.Context,
+	req *ppb.UpdateNotificationPreferencesRequest) (*ppb.UpdateNotificationPreferencesResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	channels := make([]string, 0, len(req.GetPreferences().GetChannels()))
+	for _, grpcChannel := range req.GetPreferences().GetChannels() {
+		channel, channelErr := notificationChannelFromGRPC(grpcChannel)
+		if channelErr != nil {
+			return nil, status.Error(codes.InvalidArgument, channelErr.Error())
+		}
+		if _, ok := server.notifiers[channel]; !ok {
+			return nil, status.Error(codes.FailedPrecondition, channel+" notifications are not configured")
+		}
+		channels = append(channels, channel)
+	}
+	preferences := NotificationPreferences{
+		Subject:             subject,
+		Channels:            channels,
+		Email:               req.GetPreferences().GetEmail(),
+		Phone:               req.GetPreferences().GetPhone(),
+		RemindBeforeMinutes: req.GetPreferences().GetRemindBeforeMinutes(),
+	}
+	if err = server.validate.Struct(preferences); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if preferences.hasChannel(NotificationChannelEmail) && preferences.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required for email notifications")
+	}
+	if preferences.hasChannel(NotificationChannelSMS) && preferences.Phone == "" {
+		return nil, status.Error(codes.InvalidArgument, "phone is required for SMS notifications")
+	}
+
+	if _, err = server.db.NewInsert().
+		Model(&preferences).
+		On("CONFLICT (subject) DO UPDATE").
+		Set("channels = EXCLUDED.channels").
+		Set("email = EXCLUDED.email").
+		Set("phone = EXCLUDED.phone").
+		Set("remind_before_minutes = EXCLUDED.remind_before_minutes").
+		Set("updated_at = current_timestamp").
+		Exec(ctx); err != nil {
+		return nil, status.Error(codes.Internal,
+			fmt.Errorf("failed to update notification preferences: %w", err).Error())
+	}
+	return &ppb.UpdateNotificationPreferencesResponse{Preferences: preferences.toGRPC()}, nil
+}
+
+// GetMyNotifications returns the in-app notifications of the caller, newest first.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// If the limit or the page token is not valid, codes.InvalidArgument is returned.
+func (server tasksServer) GetMyNotifications(ctx context.Context, req *ppb.GetMyNotificationsRequest) (
+	*ppb.GetMyNotificationsResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit has to be a non-negative integer")
+	}
+	if req.GetLimit() > maxPaginationLimit {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("maximum allowed limit values is %d", maxPaginationLimit))
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = maxPaginationLimit
+	}
+
+	var notifications []Notification
+	query := server.db.NewSelect().
+		Model(&notifications).
+		Where("? = ?", bun.Ident("recipient"), subject)
+	if req.GetUnreadOnly() {
+		query = query.Where("? IS NULL", bun.Ident("read_at"))
+	}
+	if req.GetPageToken() != "" {
+		cursor, cursorErr := decodePageToken(notificationsSignature, 1, req.GetPageToken())
+		if cursorErr != nil {
+			return nil, status.Error(codes.InvalidArgument, cursorErr.Error())
+		}
+		query = query.Where("? < ?::bigint", bun.Ident("id"), cursor.SortKeys[0])
+	}
+	// fetch one extra notification to know whether there is a next page
+	if err = query.OrderExpr("id DESC").Limit(limit + 1).Scan(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to fetch notifications: %w", err).Error())
+	}
+
+	var nextPageToken string
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		nextPageToken, err = encodePageToken(notificationsSignature,
+			[]string{strconv.FormatInt(notifications[len(notifications)-1].Id, 10)})
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Errorf("failed to create a page token: %w", err).Error())
+		}
+	}
+
+	grpcNotifications := make([]*ppb.Notification, len(notifications))
+	for i, notification := range notifications {
+		grpcNotifications[i] = notification.toGRPC()
+	}
+	return &ppb.GetMyNotificationsResponse{
+		Notifications: grpcNotifications,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// MarkNotificationsRead marks in-app notifications of the caller with the given ids as read.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Ids of notifications that don't exist or belong to other users are ignored.
+func (server tasksServer) MarkNotificationsRead(ctx context.Context, req *ppb.MarkNotificationsReadRequest) (
+	*ppb.MarkNotificationsReadResponse, error) {
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if len(req.GetIds()) == 0 {
+		return &ppb.MarkNotificationsReadResponse{}, nil
+	}
+
+	if _, err = server.db.NewUpdate().
+		Model((*Notification)(nil)).
+		Set("read_at = current_timestamp").
+		Where("? = ?", bun.Ident("recipient"), subject).
+		Where("? IN (?)", bun.Ident("id"), bun.In(req.GetIds())).
+		Where("? IS NULL", bun.Ident("read_at")).
+		Exec(ctx); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Errorf("failed to mark notifications as read: %w", err).Error())
+	}
+	return &ppb.MarkNotificationsReadResponse{}, nil
+}
+
+// ArchivePatientTasks cancels all the unfinished tasks of a patient with the given id in a single transaction,
+// e.g. when the patient is deleted. The statuses of the tasks and the reason are remembered,
+// so RestorePatientTasks can undo it. No tasks can be created for the patient until it is restored.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:patients permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If the patient id or the reason is missing or not valid, codes.InvalidArgument is returned.
+// Ids of the cancelled tasks are returned, archiving a patient without unfinished tasks does nothing.
+func (server tasksServer) ArchivePatientTasks(ctx context.Context, req *ppb.ArchivePatientTasksRequest) (
+	*ppb.ArchivePatientTasksResponse, error) {
+	if req.GetPatientId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "patient id is required")
+	}
+	if err := server.validate.Var(req.GetReason(), "required,max=500"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Errorf("reason is not valid: %w", err).Error())
+	}
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	var ids []int32
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		var txErr error
+		ids, txErr = archivePatientTasks(ctx, tx, req.GetPatientId(), req.GetReason(), subject)
+		return txErr
+	}); err != nil {
+		return nil, err
+	}
+	if server.patients != nil {
+		server.patients.forget(req.GetPatientId())
+	}
+	return &ppb.ArchivePatientTasksResponse{Ids: ids}, nil
+}
+
+// RestorePatientTasks moves the tasks archived by ArchivePatientTasks for a patient with the given id back
+// to the statuses they had, in a single transaction, e.g. when the patient is restored.
+// Tasks that were changed or deleted since are left as they are, and a task whose prerequisites are not
+// finished anymore is reopened instead of being started or completed.
+// Requires authentication. If authentication is not valid, codes.Unauthenticated is returned.
+// Requires the tasks:patients permission. If permissions are not sufficient, codes.PermissionDenied is returned.
+// If the patient id is missing, codes.InvalidArgument is returned.
+// Ids of the restored tasks are returned.
+func (server tasksServer) RestorePatientTasks(ctx context.Context, req *ppb.RestorePatientTasksRequest) (
+	*ppb.RestorePatientTasksResponse, error) {
+	if req.GetPatientId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "patient id is required")
+	}
+	subject, err := callerSubject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	var ids []int32
+	if err = server.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		var txErr error
+		ids, txErr = unarchivePatientTasks(ctx, tx, req.GetPatientId(), subject)
+		return txErr
+	}); err != nil {
+		return nil, err
+	}
+	if server.patients != nil {
+		server.patients.forget(req.GetPatientId())
+	}
+	return &ppb.RestorePatientTasksResponse{Ids: ids}, nil
+}
+
+// createDB opens a connection pool to the database configured by the environment.
+func createDB() (*bun.DB, error) {
+	addr, err := ms.GetRequiredEnv(envDBAddress)
+	if err != nil {
+		return nil, err
+	}
+	user, err := ms.GetRequiredEnv(envDBUser)
+	if err != nil {
+		return nil, err
+	}
+	password, err := ms.GetRequiredEnv(envDBPassword)
+	if err != nil {
+		return nil, err
+	}
+	database, err := ms.GetRequiredEnv(envDBDatabase)
+	if err != nil {
+		return nil, err
+	}
+	connector := pgdriver.NewConnector(
+		pgdriver.WithNetwork("tcp"),
+		pgdriver.WithAddr(addr),
+		pgdriver.WithUser(user),
+		pgdriver.WithPassword(password),
+		pgdriver.WithDatabase(database),
+		pgdriver.WithApplicationName(applicationName),
+		pgdriver.WithInsecure(!ms.HasSecureConnection()),
+	)
+	db := bun.NewDB(sql.OpenDB(connector), pgdialect.New())
+	db.AddQueryHook(ms.GetDBQueryHook())
+	return db, nil
+}
+
+// createTasksServer initializes a tasksServer with all the necessary fields.
+func createTasksServer() (*tasksServer, error) {
+	base, err := ms.CreateBaseServiceServer()
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := parsePermissionPolicy(ms.GetOptionalEnv(envPermissions, ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", envPermissions, err)
+	}
+	db, err := createDB()
+	if err != nil {
+		return nil, err
+	}
+	configuredNotifiers, err := createNotifiers(db)
+	if err != nil {
+		return nil, err
+	}
+	patients, err := createPatientsClient()
+	if err != nil {
+		return nil, err
+	}
+	webhookSecrets, err := createWebhookSecretsBox()
+	if err != nil {
+		return nil, err
+	}
+	return &tasksServer{
+		BaseServiceServer: base,
+		db:                db,
+		events:            newTaskEventHub(),
+		permissions:       permissions,
+		notifiers:         configuredNotifiers,
+		patients:          patients,
+		webhookSecrets:    webhookSecrets,
+		validate:          validator.New(validator.WithRequiredStructEnabled())}, nil
+}
+
+func main() {
+	// `migrate <status|up|down <migration|--all>>` manages the database schema without starting the server.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if len(os.Args) < 3 {
+			zap.L().Fatal("Usage: " + os.Args[0] + " migrate <status|up|down <migration|--all>>")
+		}
+		db, err := createDB()
+		if err != nil {
+			zap.L().Fatal("Failed to connect to the database", zap.Error(err))
+		}
+		if err = runMigrateCommand(context.Background(), db, os.Args[2:], os.Stdout); err != nil {
+			zap.L().Fatal("Failed to run migrations", zap.Error(err))
+		}
+		return
+	}
+
+	service, err := createTasksServer()
+	if err != nil {
+		zap.L().Fatal("Failed to create a task server", zap.Error(err))
+	}
+
+	// the server and the background jobs stop on SIGINT or SIGTERM, the jobs are waited for before exiting
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if _, err = migrateUp(ctx, service.db); err != nil {
+		zap.L().Fatal("Failed to migrate the database", zap.Error(err))
+	}
+	var jobs sync.WaitGroup
+	runJob := func(job func(ctx context.Context)) {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			job(ctx)
+		}()
+	}
+	runJob(func(ctx context.Context) { runScheduler(ctx, service.db, service.validate) })
+	runJob(func(ctx context.Context) { service.events.listen(ctx, service.db) })
+	runJob(func(ctx context.Context) { runTaskEventsPruner(ctx, service.db) })
+	runJob(func(ctx context.Context) { runOutboxRelay(ctx, service.db, createEventSink()) })
+	if service.webhookSecrets != nil {
+		if err = sealWebhookSecrets(ctx, service.db, service.webhookSecrets); err != nil {
+			zap.L().Fatal("Failed to encrypt webhook secrets", zap.Error(err))
+		}
+		runJob(func(ctx context.Context) { runWebhookDispatcher(ctx, service.db, service.webhookSecrets) })
+	} else {
+		zap.L().Warn(envWebhookSecretsKey + " is not configured, webhooks are not dispatched")
+	}
+	runJob(func(ctx context.Context) { runReminders(ctx, service.db, service.notifiers) })
+
+	listen, err := net.Listen("tcp", ":"+service.GetPort())
+	if err != nil {
+		zap.L().Fatal("Failed to listen", zap.Error(err))
+	}
+
+	srv := grpc.NewServer(append(ms.GetGRPCServerOptions(),
+		grpc.ChainUnaryInterceptor(service.unaryAuthInterceptor),
+		grpc.ChainStreamInterceptor(service.streamAuthInterceptor))...)
 	ppb.RegisterTasksServiceServer(srv, service)
 
+	go func() {
+		<-ctx.Done()
+		zap.L().Info("Shutting down")
+		stopServer(srv)
+	}()
+
 	zap.L().Info("Server listening on :" + service.GetPort())
 	if err = srv.Serve(listen); err != nil {
 		zap.L().Fatal("Failed to serve", zap.Error(err))
 	}
+	jobs.Wait()
+}
+
+// stopServer stops the server gracefully, waiting up to shutdownTimeout for the running calls to finish.
+// Streams like WatchTasks don't finish on their own, so the server is stopped forcibly after the timeout.
+func stopServer(srv *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		srv.Stop()
+	}
 }
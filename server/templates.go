@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+	"github.com/uptrace/bun"
+)
+
+// TaskTemplate defines a schema of recurring tasks. The scheduler creates a task from the template on every occurrence.
+type TaskTemplate struct {
+	bun.BaseModel `bun:"table:task_templates"`
+
+	Id          int32        `bun:",pk,autoincrement"`
+	Title       string       `validate:"required,min=1,max=100"`
+	Description string       ``
+	Expertise   string       ``
+	PatientId   int32        `validate:"min=1"`
+	SpecialNote string       `validate:"max=500"`
+	Priority    TaskPriority `bun:",notnull"`
+	// Recurrence is a subset of RFC 5545 recurrence rules, see parseRecurrence.
+	Recurrence string    `bun:",notnull" validate:"required"`
+	StartsAt   time.Time `bun:",notnull"`
+	// DueInHours is the due date of the created tasks relative to the occurrence. Zero means no due date.
+	DueInHours int32 `validate:"min=0"`
+	// NextRunAt is the next occurrence of the template that has no task yet.
+	NextRunAt time.Time `bun:",notnull"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// toGRPC returns a GRPC version of TaskTemplate.
+func (template TaskTemplate) toGRPC() *ppb.TaskTemplate {
+	return &ppb.TaskTemplate{
+		Id:          template.Id,
+		Title:       template.Title,
+		Description: template.Description,
+		Expertise:   template.Expertise,
+		PatientId:   template.PatientId,
+		SpecialNote: template.SpecialNote,
+		Priority:    template.Priority.toGRPC(),
+		Recurrence:  template.Recurrence,
+		StartsAt:    formatTimestamp(template.StartsAt),
+		DueInHours:  template.DueInHours,
+		NextRunAt:   formatTimestamp(template.NextRunAt),
+		CreatedAt:   formatTimestamp(template.CreatedAt),
+	}
+}
+
+// newTask returns the task of the given occurrence of the template.
+func (template TaskTemplate) newTask(occurrence time.Time) Task {
+	var dueAt time.Time
+	if template.DueInHours > 0 {
+		dueAt = occurrence.Add(time.Duration(template.DueInHours) * time.Hour)
+	}
+	return Task{
+		Complete:     false,
+		Status:       TaskStatusOpen,
+		Title:        template.Title,
+		Description:  template.Description,
+		Expertise:    template.Expertise,
+		PatientId:    template.PatientId,
+		SpecialNote:  template.SpecialNote,
+		DueAt:        dueAt,
+		Priority:     template.Priority,
+		TemplateId:   template.Id,
+		OccurrenceAt: occurrence,
+	}
+}
+
+// recurrence is a parsed recurrence rule of a task template.
+// Occurrences are counted from an anchor, the start time of the template, so they don't drift.
+type recurrence struct {
+	// months and days between consecutive occurrences, only one of them is set
+	months, days int
+}
+
+// parseRecurrence parses a subset of RFC 5545 recurrence rules, e.g. "FREQ=WEEKLY;INTERVAL=2".
+// FREQ is required and is one of DAILY, WEEKLY, MONTHLY and YEARLY. INTERVAL is optional and defaults to 1.
+func parseRecurrence(rule string) (recurrence, error) {
+	var freq string
+	interval := 1
+	for _, part := range strings.Split(strings.TrimPrefix(rule, "RRULE:"), ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return recurrence{}, fmt.Errorf("recurrence part %q has to be in the form of NAME=VALUE", part)
+		}
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			freq = strings.ToUpper(value)
+		case "INTERVAL":
+			var err error
+			if interval, err = strconv.Atoi(value); err != nil || interval <= 0 {
+				return recurrence{}, errors.New("recurrence interval has to be a positive integer")
+			}
+		default:
+			return recurrence{}, fmt.Errorf("recurrence part %s is not supported", name)
+		}
+	}
+
+	switch freq {
+	case "DAILY":
+		return recurrence{days: interval}, nil
+	case "WEEKLY":
+		const daysInWeek = 7
+		return recurrence{days: daysInWeek * interval}, nil
+	case "MONTHLY":
+		return recurrence{months: interval}, nil
+	case "YEARLY":
+		const monthsInYear = 12
+		return recurrence{months: monthsInYear * interval}, nil
+	case "":
+		return recurrence{}, errors.New("recurrence frequency is required")
+	default:
+		return recurrence{}, fmt.Errorf("recurrence frequency %s is not supported", freq)
+	}
+}
+
+// occurrence returns the n-th occurrence after the anchor, the anchor itself being the zeroth one.
+// Monthly and yearly occurrences keep the day of month of the anchor, clamped to the last day of shorter months,
+// e.g. a monthly recurrence anchored at January 31 occurs on February 28 and then on March 31.
+func (rec recurrence) occurrence(anchor time.Time, n int) time.Time {
+	if rec.months == 0 {
+		return anchor.AddDate(0, 0, n*rec.days)
+	}
+	year, month, day := anchor.Date()
+	first := time.Date(year, month+time.Month(n*rec.months), 1,
+		anchor.Hour(), anchor.Minute(), anchor.Second(), anchor.Nanosecond(), anchor.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, lastDay)-1)
+}
+
+// index returns the number of the latest occurrence after the anchor that is not after t, or -1 if t is
+// before the anchor.
+func (rec recurrence) index(anchor time.Time, t time.Time) int {
+	if t.Before(anchor) {
+		return -1
+	}
+	// estimate the index, it may be off by one because of month lengths and daylight saving time
+	var n int
+	if rec.months == 0 {
+		const hoursInDay = 24
+		n = int(t.Sub(anchor).Hours()/hoursInDay) / rec.days
+	} else {
+		const monthsInYear = 12
+		n = ((t.Year()-anchor.Year())*monthsInYear + int(t.Month()-anchor.Month())) / rec.months
+	}
+	for n > 0 && rec.occurrence(anchor, n).After(t) {
+		n--
+	}
+	for !rec.occurrence(anchor, n+1).After(t) {
+		n++
+	}
+	return n
+}
+
+// next returns the first occurrence after the anchor that is after t.
+func (rec recurrence) next(anchor time.Time, t time.Time) time.Time {
+	return rec.occurrence(anchor, rec.index(anchor, t)+1)
+}
+
+// latest returns the latest occurrence after the anchor that is not after now, along with the occurrence
+// that follows it. Now is required not to be before the anchor.
+func (rec recurrence) latest(anchor time.Time, now time.Time) (time.Time, time.Time) {
+	n := rec.index(anchor, now)
+	return rec.occurrence(anchor, n), rec.occurrence(anchor, n+1)
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    recurrence
+		wantErr bool
+	}{
+		{rule: "FREQ=DAILY", want: recurrence{days: 1}},
+		{rule: "FREQ=WEEKLY;INTERVAL=2", want: recurrence{days: 14}},
+		{rule: "RRULE:FREQ=MONTHLY;INTERVAL=3", want: recurrence{months: 3}},
+		{rule: "freq=yearly", want: recurrence{months: 12}},
+		{rule: "INTERVAL=2;FREQ=DAILY", want: recurrence{days: 2}},
+		{rule: "", wantErr: true},
+		{rule: "INTERVAL=2", wantErr: true},
+		{rule: "FREQ=HOURLY", wantErr: true},
+		{rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{rule: "FREQ=DAILY;INTERVAL=x", wantErr: true},
+		{rule: "FREQ=DAILY;BYDAY=MO", wantErr: true},
+		{rule: "FREQ", wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := parseRecurrence(test.rule)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("parseRecurrence(%q) = %+v, want an error", test.rule, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseRecurrence(%q) returned an error: %v", test.rule, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("parseRecurrence(%q) = %+v, want %+v", test.rule, got, test.want)
+		}
+	}
+}
+
+func date(year int, month time.Month, day int, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	tests := []struct {
+		name   string
+		rec    recurrence
+		anchor time.Time
+		after  time.Time
+		want   time.Time
+	}{
+		{
+			name:   "daily",
+			rec:    recurrence{days: 1},
+			anchor: date(2024, time.March, 1, 9),
+			after:  date(2024, time.March, 1, 9),
+			want:   date(2024, time.March, 2, 9),
+		},
+		{
+			name:   "weekly between occurrences",
+			rec:    recurrence{days: 7},
+			anchor: date(2024, time.March, 1, 9),
+			after:  date(2024, time.March, 10, 0),
+			want:   date(2024, time.March, 15, 9),
+		},
+		{
+			name:   "before the anchor",
+			rec:    recurrence{days: 7},
+			anchor: date(2024, time.March, 1, 9),
+			after:  date(2024, time.February, 1, 0),
+			want:   date(2024, time.March, 1, 9),
+		},
+		{
+			name:   "monthly from the last day of january is clamped in february",
+			rec:    recurrence{months: 1},
+			anchor: date(2024, time.January, 31, 9),
+			after:  date(2024, time.January, 31, 9),
+			want:   date(2024, time.February, 29, 9),
+		},
+		{
+			name:   "monthly from the last day of january doesn't drift after february",
+			rec:    recurrence{months: 1},
+			anchor: date(2024, time.January, 31, 9),
+			after:  date(2024, time.February, 29, 9),
+			want:   date(2024, time.March, 31, 9),
+		},
+		{
+			name:   "monthly from the last day of january in april",
+			rec:    recurrence{months: 1},
+			anchor: date(2024, time.January, 31, 9),
+			after:  date(2024, time.April, 1, 0),
+			want:   date(2024, time.April, 30, 9),
+		},
+		{
+			name:   "every two months",
+			rec:    recurrence{months: 2},
+			anchor: date(2024, time.January, 15, 9),
+			after:  date(2024, time.February, 20, 0),
+			want:   date(2024, time.March, 15, 9),
+		},
+		{
+			name:   "yearly from a leap day",
+			rec:    recurrence{months: 12},
+			anchor: date(2024, time.February, 29, 9),
+			after:  date(2024, time.February, 29, 9),
+			want:   date(2025, time.February, 28, 9),
+		},
+		{
+			name:   "yearly from a leap day gets back to it",
+			rec:    recurrence{months: 12},
+			anchor: date(2024, time.February, 29, 9),
+			after:  date(2027, time.March, 1, 0),
+			want:   date(2028, time.February, 29, 9),
+		},
+	}
+	for _, test := range tests {
+		if got := test.rec.next(test.anchor, test.after); !got.Equal(test.want) {
+			t.Errorf("%s: next = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestRecurrenceLatest(t *testing.T) {
+	tests := []struct {
+		name     string
+		rec      recurrence
+		anchor   time.Time
+		now      time.Time
+		want     time.Time
+		wantNext time.Time
+	}{
+		{
+			name:     "at the anchor",
+			rec:      recurrence{days: 1},
+			anchor:   date(2024, time.March, 1, 9),
+			now:      date(2024, time.March, 1, 9),
+			want:     date(2024, time.March, 1, 9),
+			wantNext: date(2024, time.March, 2, 9),
+		},
+		{
+			name:     "missed occurrences are skipped",
+			rec:      recurrence{days: 1},
+			anchor:   date(2024, time.March, 1, 9),
+			now:      date(2024, time.March, 5, 12),
+			want:     date(2024, time.March, 5, 9),
+			wantNext: date(2024, time.March, 6, 9),
+		},
+		{
+			name:     "just before an occurrence",
+			rec:      recurrence{days: 7},
+			anchor:   date(2024, time.March, 1, 9),
+			now:      date(2024, time.March, 8, 8),
+			want:     date(2024, time.March, 1, 9),
+			wantNext: date(2024, time.March, 8, 9),
+		},
+		{
+			name:     "monthly from the end of a month",
+			rec:      recurrence{months: 1},
+			anchor:   date(2024, time.January, 31, 9),
+			now:      date(2024, time.March, 3, 9),
+			want:     date(2024, time.February, 29, 9),
+			wantNext: date(2024, time.March, 31, 9),
+		},
+		{
+			name:     "years later",
+			rec:      recurrence{months: 1},
+			anchor:   date(2024, time.January, 31, 9),
+			now:      date(2026, time.June, 30, 10),
+			want:     date(2026, time.June, 30, 9),
+			wantNext: date(2026, time.July, 31, 9),
+		},
+	}
+	for _, test := range tests {
+		got, gotNext := test.rec.latest(test.anchor, test.now)
+		if !got.Equal(test.want) || !gotNext.Equal(test.wantNext) {
+			t.Errorf("%s: latest = %s, %s, want %s, %s", test.name, got, gotNext, test.want, test.wantNext)
+		}
+	}
+}
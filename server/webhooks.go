@@ -0,0 +1,419 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+
+	ppb "github.com/TekClinic/Tasks-MicroService/tasks_protobuf"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// webhookDispatchInterval is how often the dispatcher looks for deliveries to attempt.
+	webhookDispatchInterval = 5 * time.Second
+	// webhookBatchSize is the maximum number of deliveries attempted on each run of the dispatcher.
+	webhookBatchSize = 50
+	// webhookMaxAttempts is the number of failed attempts after which a delivery is dead and isn't retried anymore.
+	webhookMaxAttempts = 15
+
+	// Headers of the event requests. The signature is "sha256=" followed by the hex encoded
+	// HMAC-SHA256 of the timestamp, a dot and the body, keyed with the secret of the webhook.
+	eventIdHeader        = "X-Event-Id"
+	eventTimestampHeader = "X-Tasks-Timestamp"
+	eventSignatureHeader = "X-Tasks-Signature"
+)
+
+var (
+	// errInternalWebhookAddress is returned for webhooks that would be delivered to the deployment, see publicAddress.
+	errInternalWebhookAddress = errors.New("webhooks can't be delivered to loopback, private or link-local addresses")
+	// errWebhookNotFound is returned for deliveries whose webhook was deleted while they were attempted.
+	errWebhookNotFound = errors.New("webhook was deleted")
+)
+
+// States of webhook deliveries, as stored in the database.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+// Webhook defines a schema of webhooks, which are subscriptions of external services to task events.
+type Webhook struct {
+	bun.BaseModel `bun:"table:task_webhooks"`
+
+	Id  int32  `bun:",pk,autoincrement"`
+	Url string `bun:",notnull" validate:"required,http_url,max=2000"`
+	// EventTypes are the types of the delivered events, empty means all the types.
+	EventTypes []string `bun:",array"`
+	// Secret signs the deliveries. It is stored only sealed in EncryptedSecret, see secretBox.
+	Secret          string    `bun:"-" validate:"min=16,max=200"`
+	EncryptedSecret []byte    `bun:",notnull"`
+	CreatedBy       string    `bun:",notnull"`
+	CreatedAt       time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// toGRPC returns a GRPC version of Webhook. The secret is never returned.
+func (webhook Webhook) toGRPC() *ppb.Webhook {
+	eventTypes := make([]ppb.TaskEvent_Type, len(webhook.EventTypes))
+	for i, eventType := range webhook.EventTypes {
+		eventTypes[i] = taskEventTypeToGRPC(eventType)
+	}
+	return &ppb.Webhook{
+		Id:         webhook.Id,
+		Url:        webhook.Url,
+		EventTypes: eventTypes,
+		CreatedBy:  webhook.CreatedBy,
+		CreatedAt:  formatTimestamp(webhook.CreatedAt),
+	}
+}
+
+// WebhookDelivery defines a schema of deliveries of task events to webhooks.
+// Deliveries are created in the transaction of the change, along with the outbox event.
+type WebhookDelivery struct {
+	bun.BaseModel `bun:"table:task_webhook_deliveries"`
+
+	Id        int64 `bun:",pk,autoincrement"`
+	WebhookId int32 `bun:",notnull"`
+	// EventId is the id of the outbox event, the same for all the webhooks.
+	EventId   int64  `bun:",notnull"`
+	EventType string `bun:",notnull"`
+	TaskId    int32  `bun:",notnull"`
+	// Payload is the task after the change, in the JSON encoding of the GRPC version.
+	Payload        json.RawMessage `bun:"type:jsonb,notnull"`
+	State          string          `bun:",notnull,default:'pending'"`
+	Attempts       int32           `bun:",notnull"`
+	LastError      string          `bun:",nullzero"`
+	LastStatusCode int32           `bun:",nullzero"`
+	NextAttemptAt  time.Time       `bun:",nullzero,notnull,default:current_timestamp"`
+	DeliveredAt    time.Time       `bun:",nullzero"`
+	CreatedAt      time.Time       `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// toGRPC returns a GRPC version of WebhookDelivery.
+func (delivery WebhookDelivery) toGRPC() *ppb.WebhookDelivery {
+	var state ppb.WebhookDelivery_State
+	switch delivery.State {
+	case WebhookDeliveryPending:
+		state = ppb.WebhookDelivery_STATE_PENDING
+	case WebhookDeliveryDelivered:
+		state = ppb.WebhookDelivery_STATE_DELIVERED
+	case WebhookDeliveryDead:
+		state = ppb.WebhookDelivery_STATE_DEAD
+	}
+	nextAttemptAt := ""
+	if delivery.State == WebhookDeliveryPending {
+		nextAttemptAt = formatTimestamp(delivery.NextAttemptAt)
+	}
+	return &ppb.WebhookDelivery{
+		Id:             delivery.Id,
+		WebhookId:      delivery.WebhookId,
+		EventId:        delivery.EventId,
+		EventType:      taskEventTypeToGRPC(delivery.EventType),
+		TaskId:         delivery.TaskId,
+		State:          state,
+		Attempts:       delivery.Attempts,
+		LastError:      delivery.LastError,
+		LastStatusCode: delivery.LastStatusCode,
+		NextAttemptAt:  nextAttemptAt,
+		DeliveredAt:    formatTimestamp(delivery.DeliveredAt),
+		CreatedAt:      formatTimestamp(delivery.CreatedAt),
+	}
+}
+
+// webhookDeliveryStateFromGRPC converts a GRPC delivery state to the one stored in the database.
+// The unspecified state is converted to an empty string.
+func webhookDeliveryStateFromGRPC(state ppb.WebhookDelivery_State) (string, error) {
+	switch state {
+	case ppb.WebhookDelivery_STATE_UNSPECIFIED:
+		return "", nil
+	case ppb.WebhookDelivery_STATE_PENDING:
+		return WebhookDeliveryPending, nil
+	case ppb.WebhookDelivery_STATE_DELIVERED:
+		return WebhookDeliveryDelivered, nil
+	case ppb.WebhookDelivery_STATE_DEAD:
+		return WebhookDeliveryDead, nil
+	default:
+		return "", fmt.Errorf("unknown delivery state %d", state)
+	}
+}
+
+// enqueueWebhookDeliveries creates deliveries of an outbox event to all the webhooks subscribed to its type.
+func enqueueWebhookDeliveries(ctx context.Context, tx bun.Tx, event OutboxEvent, eventType string) error {
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO task_webhook_deliveries (webhook_id, event_id, event_type, task_id, payload) "+
+			"SELECT id, ?, ?, ?, ?::jsonb FROM task_webhooks "+
+			"WHERE coalesce(cardinality(event_types), 0) = 0 OR ? = ANY(event_types)",
+		event.Id, eventType, event.TaskId, string(event.Payload), eventType); err != nil {
+		return status.Error(codes.Internal, fmt.Errorf("failed to create webhook deliveries: %w", err).Error())
+	}
+	return nil
+}
+
+// signEvent returns the signature of an event request body sent at the given unix time.
+func signEvent(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// postEvent posts the event as JSON to the URL, signed with the secret unless it is empty.
+// The status code of the response is returned even if it is not 2xx, in which case an error is returned as well.
+func postEvent(ctx context.Context, client *http.Client, url string, secret string, event domainEvent) (int, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(eventIdHeader, strconv.FormatInt(event.Id, 10))
+	if secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(eventTimestampHeader, timestamp)
+		req.Header.Set(eventSignatureHeader, signEvent(secret, timestamp, body))
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return res.StatusCode, fmt.Errorf("webhook responded with %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
+
+// publicAddress reports whether ip is a public unicast address, which webhooks are allowed to be delivered to.
+// Loopback, private, link-local, shared, unspecified and multicast addresses belong to the deployment,
+// delivering to them would let the owners of webhooks reach services that aren't exposed.
+func publicAddress(ip net.IP) bool {
+	thisNetwork := net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(8, 32)}
+	sharedAddressSpace := net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !thisNetwork.Contains(ip) && !sharedAddressSpace.Contains(ip)
+}
+
+// checkWebhookURL verifies that the host of a webhook URL resolves only to public addresses.
+// The addresses are verified again on every connection, see webhookDialControl, since DNS records can change.
+func checkWebhookURL(ctx context.Context, resolver *net.Resolver, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host := parsed.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !publicAddress(ip) {
+			return errInternalWebhookAddress
+		}
+		return nil
+	}
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !publicAddress(addr.IP) {
+			return errInternalWebhookAddress
+		}
+	}
+	return nil
+}
+
+// webhookDialControl refuses connections to addresses that aren't public once the host is resolved,
+// so a webhook can't reach the deployment by changing its DNS records or by redirecting.
+func webhookDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !publicAddress(ip) {
+		return errInternalWebhookAddress
+	}
+	return nil
+}
+
+// newWebhookClient returns the HTTP client of webhook deliveries, which connects to public addresses only.
+// Proxies aren't used, the addresses of the webhooks couldn't be verified through them.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{Timeout: sinkTimeout, Control: webhookDialControl}
+	return &http.Client{
+		Timeout: sinkTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: sinkTimeout,
+			ForceAttemptHTTP2:   true,
+		},
+	}
+}
+
+// settle records the result of an attempt of the delivery made at now.
+// A failed delivery is scheduled for a retry with an exponential backoff, or dies after webhookMaxAttempts attempts.
+func (delivery *WebhookDelivery) settle(statusCode int, err error, now time.Time) {
+	delivery.Attempts++
+	delivery.LastStatusCode = int32(statusCode)
+	if err == nil {
+		delivery.State = WebhookDeliveryDelivered
+		delivery.DeliveredAt = now
+		return
+	}
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= webhookMaxAttempts {
+		delivery.State = WebhookDeliveryDead
+		return
+	}
+	delivery.NextAttemptAt = now.Add(retryDelay(delivery.Attempts))
+}
+
+// runWebhookDispatcher attempts the due webhook deliveries until ctx is done.
+// Every replica runs the dispatcher, replicas skip the deliveries that are being attempted by others.
+// The secrets of the webhooks are opened with box.
+func runWebhookDispatcher(ctx context.Context, db *bun.DB, box *secretBox) {
+	client := newWebhookClient()
+	ticker := time.NewTicker(webhookDispatchInterval)
+	defer ticker.Stop()
+	for {
+		if err := dispatchWebhooks(ctx, db, client, box); err != nil {
+			zap.L().Error("Failed to dispatch webhook deliveries", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchWebhooks attempts a batch of due deliveries, and removes old delivered ones.
+// Deliveries are claimed the same way as outbox events are, see relayOutbox: they are leased for outboxLease,
+// attempted outside of any transaction, and their results are stored only if the lease is still held.
+func dispatchWebhooks(ctx context.Context, db *bun.DB, client *http.Client, box *secretBox) error {
+	now := time.Now()
+	// Postgres keeps microseconds, the lease is compared to the stored one when the results are written
+	leaseUntil := now.Add(outboxLease).Truncate(time.Microsecond)
+	var deliveries []WebhookDelivery
+	if err := db.NewUpdate().
+		Model((*WebhookDelivery)(nil)).
+		Set("next_attempt_at = ?", leaseUntil).
+		Where("? IN (?)", bun.Ident("id"), db.NewSelect().
+			Model((*WebhookDelivery)(nil)).
+			Column("id").
+			Where("? = ?", bun.Ident("state"), WebhookDeliveryPending).
+			Where("? <= ?", bun.Ident("next_attempt_at"), now).
+			Order("id").
+			Limit(webhookBatchSize).
+			For("UPDATE SKIP LOCKED")).
+		Returning("*").
+		Scan(ctx, &deliveries); err != nil {
+		return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	slices.SortFunc(deliveries, func(a, b WebhookDelivery) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	if len(deliveries) > 0 {
+		webhookIds := make([]int32, len(deliveries))
+		for i, delivery := range deliveries {
+			webhookIds[i] = delivery.WebhookId
+		}
+		var webhooks []Webhook
+		if err := db.NewSelect().
+			Model(&webhooks).
+			Where("? IN (?)", bun.Ident("id"), bun.In(webhookIds)).
+			Scan(ctx); err != nil {
+			return fmt.Errorf("failed to fetch webhooks: %w", err)
+		}
+		webhooksByID := make(map[int32]Webhook, len(webhooks))
+		for _, webhook := range webhooks {
+			webhooksByID[webhook.Id] = webhook
+		}
+
+		attempted := attemptWebhookDeliveries(ctx, client, box, webhooksByID, deliveries, leaseUntil)
+		if err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			for i := range attempted {
+				// a delivery whose lease expired may have been claimed by another dispatcher, its results win
+				if _, err := tx.NewUpdate().
+					Model(&attempted[i]).
+					Column("state", "attempts", "last_error", "last_status_code", "next_attempt_at", "delivered_at").
+					WherePK().
+					Where("? = ?", bun.Ident("next_attempt_at"), leaseUntil).
+					Exec(ctx); err != nil {
+					return fmt.Errorf("failed to update a webhook delivery: %w", err)
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if _, err := db.NewDelete().
+		Model((*WebhookDelivery)(nil)).
+		Where("? = ?", bun.Ident("state"), WebhookDeliveryDelivered).
+		Where("? < ?", bun.Ident("delivered_at"), time.Now().Add(-outboxRetention)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to remove delivered webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// attemptWebhookDeliveries posts the deliveries to their webhooks in order and settles each of them with the result.
+// Attempts stop when one could outlast the lease or ctx is done. The attempted deliveries are returned.
+func attemptWebhookDeliveries(ctx context.Context, client *http.Client, box *secretBox, webhooks map[int32]Webhook,
+	deliveries []WebhookDelivery, leaseUntil time.Time) []WebhookDelivery {
+	for i := range deliveries {
+		if ctx.Err() != nil || time.Until(leaseUntil) < sinkTimeout {
+			return deliveries[:i]
+		}
+		statusCode, err := attemptWebhookDelivery(ctx, client, box, webhooks, deliveries[i])
+		deliveries[i].settle(statusCode, err, time.Now())
+		if deliveries[i].State == WebhookDeliveryDead {
+			zap.L().Warn("Webhook delivery is dead", zap.Int64("id", deliveries[i].Id),
+				zap.Int32("webhook_id", deliveries[i].WebhookId), zap.Error(err))
+		}
+	}
+	return deliveries
+}
+
+// attemptWebhookDelivery posts a delivery to its webhook, signed with the secret of the webhook.
+func attemptWebhookDelivery(ctx context.Context, client *http.Client, box *secretBox, webhooks map[int32]Webhook,
+	delivery WebhookDelivery) (int, error) {
+	webhook, ok := webhooks[delivery.WebhookId]
+	if !ok {
+		return 0, errWebhookNotFound
+	}
+	secret, err := box.open(webhook.EncryptedSecret)
+	if err != nil {
+		return 0, err
+	}
+	event := domainEvent{
+		Id:         delivery.EventId,
+		Type:       domainEventType(delivery.EventType),
+		TaskId:     delivery.TaskId,
+		OccurredAt: delivery.CreatedAt,
+		Task:       delivery.Payload,
+	}
+	postCtx, cancel := context.WithTimeout(ctx, sinkTimeout)
+	defer cancel()
+	return postEvent(postCtx, client, webhook.Url, secret, event)
+}
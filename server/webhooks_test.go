@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSignEvent(t *testing.T) {
+	tests := []struct {
+		timestamp string
+		want      string
+	}{
+		{timestamp: "1700000000", want: "sha256=4bcaced68dfea90a68df035b89cb7fb26692d899d32a1ccb1b0616cf48e4d1ed"},
+		{timestamp: "1700000001", want: "sha256=c3bde3c3645f35c5bad9f7d0ac0ece4b0b2703b3e1b4f20e0162e18c73c6d5ba"},
+	}
+	for _, test := range tests {
+		if got := signEvent("0123456789abcdef", test.timestamp, []byte(`{"id":1}`)); got != test.want {
+			t.Errorf("signEvent at %s = %q, want %q", test.timestamp, got, test.want)
+		}
+	}
+}
+
+func TestWebhookDeliverySettle(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	errRejected := errors.New("webhook responded with 500 Internal Server Error")
+	tests := []struct {
+		name         string
+		attempts     int32
+		statusCode   int
+		err          error
+		wantState    string
+		wantAttempts int32
+		wantNext     time.Time
+	}{
+		{name: "delivered", statusCode: http.StatusOK, wantState: WebhookDeliveryDelivered, wantAttempts: 1},
+		{
+			name:         "delivered after failures",
+			attempts:     3,
+			statusCode:   http.StatusNoContent,
+			wantState:    WebhookDeliveryDelivered,
+			wantAttempts: 4,
+		},
+		{
+			name:         "first failure",
+			statusCode:   http.StatusInternalServerError,
+			err:          errRejected,
+			wantState:    WebhookDeliveryPending,
+			wantAttempts: 1,
+			wantNext:     now.Add(time.Second),
+		},
+		{
+			name:         "backoff",
+			attempts:     4,
+			err:          errRejected,
+			wantState:    WebhookDeliveryPending,
+			wantAttempts: 5,
+			wantNext:     now.Add(16 * time.Second),
+		},
+		{
+			name:         "last retry",
+			attempts:     webhookMaxAttempts - 2,
+			err:          errRejected,
+			wantState:    WebhookDeliveryPending,
+			wantAttempts: webhookMaxAttempts - 1,
+			wantNext:     now.Add(retryDelay(webhookMaxAttempts - 1)),
+		},
+		{
+			name:         "dead",
+			attempts:     webhookMaxAttempts - 1,
+			statusCode:   http.StatusBadGateway,
+			err:          errRejected,
+			wantState:    WebhookDeliveryDead,
+			wantAttempts: webhookMaxAttempts,
+		},
+	}
+	for _, test := range tests {
+		delivery := WebhookDelivery{Id: 1, State: WebhookDeliveryPending, Attempts: test.attempts}
+		delivery.settle(test.statusCode, test.err, now)
+		if delivery.State != test.wantState {
+			t.Errorf("%s: state = %q, want %q", test.name, delivery.State, test.wantState)
+		}
+		if delivery.Attempts != test.wantAttempts {
+			t.Errorf("%s: attempts = %d, want %d", test.name, delivery.Attempts, test.wantAttempts)
+		}
+		if delivery.LastStatusCode != int32(test.statusCode) {
+			t.Errorf("%s: last status code = %d, want %d", test.name, delivery.LastStatusCode, test.statusCode)
+		}
+		if !delivery.NextAttemptAt.Equal(test.wantNext) {
+			t.Errorf("%s: next attempt at %s, want %s", test.name, delivery.NextAttemptAt, test.wantNext)
+		}
+		if delivered := !delivery.DeliveredAt.IsZero(); delivered != (test.wantState == WebhookDeliveryDelivered) {
+			t.Errorf("%s: delivered at %s", test.name, delivery.DeliveredAt)
+		}
+		if test.err != nil && delivery.LastError != test.err.Error() {
+			t.Errorf("%s: last error = %q, want %q", test.name, delivery.LastError, test.err.Error())
+		}
+	}
+}
+
+func testSecretBox(t *testing.T) *secretBox {
+	t.Helper()
+	box, err := newSecretBox(bytes.Repeat([]byte{7}, secretsKeySize))
+	if err != nil {
+		t.Fatalf("newSecretBox returned an error: %v", err)
+	}
+	return box
+}
+
+func TestAttemptWebhookDeliveries(t *testing.T) {
+	const secret = "0123456789abcdef"
+	var signatures []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(eventSignatureHeader) != signEvent(secret, r.Header.Get(eventTimestampHeader), body) {
+			t.Errorf("request of event %s is not signed with the secret", r.Header.Get(eventIdHeader))
+		}
+		signatures = append(signatures, r.Header.Get(eventIdHeader))
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	box := testSecretBox(t)
+	sealed, err := box.seal(secret)
+	if err != nil {
+		t.Fatalf("seal returned an error: %v", err)
+	}
+	webhooks := map[int32]Webhook{
+		1: {Id: 1, Url: srv.URL + "/ok", EncryptedSecret: sealed},
+		2: {Id: 2, Url: srv.URL + "/failing", EncryptedSecret: sealed},
+	}
+	deliveries := []WebhookDelivery{
+		{Id: 1, WebhookId: 1, EventId: 10, State: WebhookDeliveryPending},
+		{Id: 2, WebhookId: 2, EventId: 11, State: WebhookDeliveryPending, Attempts: webhookMaxAttempts - 1},
+		{Id: 3, WebhookId: 2, EventId: 12, State: WebhookDeliveryPending},
+		{Id: 4, WebhookId: 3, EventId: 13, State: WebhookDeliveryPending},
+	}
+
+	attempted := attemptWebhookDeliveries(context.Background(), srv.Client(), box, webhooks, deliveries,
+		time.Now().Add(outboxLease))
+	if len(attempted) != len(deliveries) {
+		t.Fatalf("attempted %d deliveries, want %d", len(attempted), len(deliveries))
+	}
+	if len(signatures) != 3 || signatures[0] != "10" || signatures[1] != "11" || signatures[2] != "12" {
+		t.Errorf("posted events %v, want 10, 11 and 12 in order", signatures)
+	}
+	for i, want := range []struct {
+		state      string
+		statusCode int32
+	}{
+		{state: WebhookDeliveryDelivered, statusCode: http.StatusOK},
+		{state: WebhookDeliveryDead, statusCode: http.StatusServiceUnavailable},
+		{state: WebhookDeliveryPending, statusCode: http.StatusServiceUnavailable},
+		{state: WebhookDeliveryPending},
+	} {
+		if attempted[i].State != want.state || attempted[i].LastStatusCode != want.statusCode {
+			t.Errorf("delivery %d is %s with %d, want %s with %d", attempted[i].Id,
+				attempted[i].State, attempted[i].LastStatusCode, want.state, want.statusCode)
+		}
+	}
+	if attempted[3].LastError != errWebhookNotFound.Error() {
+		t.Errorf("delivery of a deleted webhook failed with %q, want %q", attempted[3].LastError, errWebhookNotFound)
+	}
+
+	attempted = attemptWebhookDeliveries(context.Background(), srv.Client(), box, webhooks, deliveries[:1],
+		time.Now().Add(sinkTimeout/2))
+	if len(attempted) != 0 {
+		t.Errorf("attempted %d deliveries with an expiring lease, want none", len(attempted))
+	}
+}
+
+func TestPublicAddress(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.216.34", want: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "10.1.2.3"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "fd00::1"},
+		{ip: "100.64.0.1"},
+		{ip: "0.0.0.0"},
+		{ip: "0.1.2.3"},
+		{ip: "::"},
+		{ip: "224.0.0.1"},
+		{ip: "255.255.255.255"},
+		{ip: "::ffff:127.0.0.1"},
+		{ip: "::ffff:10.0.0.1"},
+	}
+	for _, test := range tests {
+		if got := publicAddress(net.ParseIP(test.ip)); got != test.want {
+			t.Errorf("publicAddress(%s) = %t, want %t", test.ip, got, test.want)
+		}
+	}
+}
+
+func TestCheckWebhookURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://93.184.216.34/hooks"},
+		{url: "https://[2606:2800:220:1:248:1893:25c8:1946]:8443/hooks"},
+		{url: "http://127.0.0.1:8080/", wantErr: true},
+		{url: "http://[::1]/", wantErr: true},
+		{url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{url: "http://10.0.0.5/", wantErr: true},
+		{url: "http://localhost:9000/", wantErr: true},
+	}
+	for _, test := range tests {
+		err := checkWebhookURL(context.Background(), net.DefaultResolver, test.url)
+		if (err != nil) != test.wantErr {
+			t.Errorf("checkWebhookURL(%q) = %v, want an error: %t", test.url, err, test.wantErr)
+		}
+	}
+}
+
+func TestWebhookClientRefusesInternalAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("webhook client connected to a loopback address")
+	}))
+	defer srv.Close()
+
+	res, err := newWebhookClient().Get(srv.URL)
+	if err == nil {
+		res.Body.Close()
+	}
+	if !errors.Is(err, errInternalWebhookAddress) {
+		t.Errorf("request to %s failed with %v, want %v", srv.URL, err, errInternalWebhookAddress)
+	}
+}
+
+func TestSecretBox(t *testing.T) {
+	box := testSecretBox(t)
+	first, err := box.seal("0123456789abcdef")
+	if err != nil {
+		t.Fatalf("seal returned an error: %v", err)
+	}
+	second, err := box.seal("0123456789abcdef")
+	if err != nil {
+		t.Fatalf("seal returned an error: %v", err)
+	}
+	if bytes.Equal(first, second) {
+		t.Error("sealing a secret twice gave the same result")
+	}
+	if bytes.Contains(first, []byte("0123456789abcdef")) {
+		t.Error("sealed secret contains the plaintext")
+	}
+	if secret, err := box.open(first); err != nil || secret != "0123456789abcdef" {
+		t.Errorf("open = %q, %v, want the sealed secret", secret, err)
+	}
+
+	otherBox, err := newSecretBox(bytes.Repeat([]byte{8}, secretsKeySize))
+	if err != nil {
+		t.Fatalf("newSecretBox returned an error: %v", err)
+	}
+	if _, err = otherBox.open(first); err == nil {
+		t.Error("a secret was opened with another key")
+	}
+	first[len(first)-1] ^= 1
+	if _, err = box.open(first); err == nil {
+		t.Error("a tampered secret was opened")
+	}
+	if _, err = box.open([]byte{1, 2, 3}); !errors.Is(err, errSealedSecretTooShort) {
+		t.Errorf("open of a short secret = %v, want %v", err, errSealedSecretTooShort)
+	}
+	if _, err = newSecretBox([]byte("short")); err == nil {
+		t.Error("newSecretBox accepted a short key")
+	}
+}
@@ -9,6 +9,8 @@ package tasks_protobuf
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,12 +23,354 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// TaskOrderBy is the order of listed tasks. Ties are broken by id.
+type TaskOrderBy int32
+
+const (
+	TaskOrderBy_TASK_ORDER_BY_UNSPECIFIED TaskOrderBy = 0
+	TaskOrderBy_TASK_ORDER_BY_DUE_AT      TaskOrderBy = 1
+	TaskOrderBy_TASK_ORDER_BY_PRIORITY    TaskOrderBy = 2
+	TaskOrderBy_TASK_ORDER_BY_ID          TaskOrderBy = 3
+	TaskOrderBy_TASK_ORDER_BY_CREATED_AT  TaskOrderBy = 4
+	TaskOrderBy_TASK_ORDER_BY_TITLE       TaskOrderBy = 5
+)
+
+// Enum value maps for TaskOrderBy.
+var (
+	TaskOrderBy_name = map[int32]string{
+		0: "TASK_ORDER_BY_UNSPECIFIED",
+		1: "TASK_ORDER_BY_DUE_AT",
+		2: "TASK_ORDER_BY_PRIORITY",
+		3: "TASK_ORDER_BY_ID",
+		4: "TASK_ORDER_BY_CREATED_AT",
+		5: "TASK_ORDER_BY_TITLE",
+	}
+	TaskOrderBy_value = map[string]int32{
+		"TASK_ORDER_BY_UNSPECIFIED": 0,
+		"TASK_ORDER_BY_DUE_AT":      1,
+		"TASK_ORDER_BY_PRIORITY":    2,
+		"TASK_ORDER_BY_ID":          3,
+		"TASK_ORDER_BY_CREATED_AT":  4,
+		"TASK_ORDER_BY_TITLE":       5,
+	}
+)
+
+func (x TaskOrderBy) Enum() *TaskOrderBy {
+	p := new(TaskOrderBy)
+	*p = x
+	return p
+}
+
+func (x TaskOrderBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskOrderBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_service_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskOrderBy) Type() protoreflect.EnumType {
+	return &file_tasks_service_proto_enumTypes[0]
+}
+
+func (x TaskOrderBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskOrderBy.Descriptor instead.
+func (TaskOrderBy) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{0}
+}
+
+type NotificationChannel int32
+
+const (
+	NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED NotificationChannel = 0
+	// Notifications are fetched with GetMyNotifications.
+	NotificationChannel_NOTIFICATION_CHANNEL_IN_APP NotificationChannel = 1
+	NotificationChannel_NOTIFICATION_CHANNEL_EMAIL  NotificationChannel = 2
+	NotificationChannel_NOTIFICATION_CHANNEL_SMS    NotificationChannel = 3
+)
+
+// Enum value maps for NotificationChannel.
+var (
+	NotificationChannel_name = map[int32]string{
+		0: "NOTIFICATION_CHANNEL_UNSPECIFIED",
+		1: "NOTIFICATION_CHANNEL_IN_APP",
+		2: "NOTIFICATION_CHANNEL_EMAIL",
+		3: "NOTIFICATION_CHANNEL_SMS",
+	}
+	NotificationChannel_value = map[string]int32{
+		"NOTIFICATION_CHANNEL_UNSPECIFIED": 0,
+		"NOTIFICATION_CHANNEL_IN_APP":      1,
+		"NOTIFICATION_CHANNEL_EMAIL":       2,
+		"NOTIFICATION_CHANNEL_SMS":         3,
+	}
+)
+
+func (x NotificationChannel) Enum() *NotificationChannel {
+	p := new(NotificationChannel)
+	*p = x
+	return p
+}
+
+func (x NotificationChannel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationChannel) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_service_proto_enumTypes[1].Descriptor()
+}
+
+func (NotificationChannel) Type() protoreflect.EnumType {
+	return &file_tasks_service_proto_enumTypes[1]
+}
+
+func (x NotificationChannel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationChannel.Descriptor instead.
+func (NotificationChannel) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{1}
+}
+
+type TaskEvent_Type int32
+
+const (
+	TaskEvent_TYPE_UNSPECIFIED TaskEvent_Type = 0
+	TaskEvent_TYPE_CREATED     TaskEvent_Type = 1
+	TaskEvent_TYPE_UPDATED     TaskEvent_Type = 2
+	TaskEvent_TYPE_COMPLETED   TaskEvent_Type = 3
+	TaskEvent_TYPE_DELETED     TaskEvent_Type = 4
+	TaskEvent_TYPE_RESTORED    TaskEvent_Type = 5
+	TaskEvent_TYPE_ASSIGNED    TaskEvent_Type = 6
+)
+
+// Enum value maps for TaskEvent_Type.
+var (
+	TaskEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_CREATED",
+		2: "TYPE_UPDATED",
+		3: "TYPE_COMPLETED",
+		4: "TYPE_DELETED",
+		5: "TYPE_RESTORED",
+		6: "TYPE_ASSIGNED",
+	}
+	TaskEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_CREATED":     1,
+		"TYPE_UPDATED":     2,
+		"TYPE_COMPLETED":   3,
+		"TYPE_DELETED":     4,
+		"TYPE_RESTORED":    5,
+		"TYPE_ASSIGNED":    6,
+	}
+)
+
+func (x TaskEvent_Type) Enum() *TaskEvent_Type {
+	p := new(TaskEvent_Type)
+	*p = x
+	return p
+}
+
+func (x TaskEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_service_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskEvent_Type) Type() protoreflect.EnumType {
+	return &file_tasks_service_proto_enumTypes[2]
+}
+
+func (x TaskEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskEvent_Type.Descriptor instead.
+func (TaskEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{59, 0}
+}
+
+type WebhookDelivery_State int32
+
+const (
+	WebhookDelivery_STATE_UNSPECIFIED WebhookDelivery_State = 0
+	// The event wasn't delivered yet, it is retried at next_attempt_at.
+	WebhookDelivery_STATE_PENDING   WebhookDelivery_State = 1
+	WebhookDelivery_STATE_DELIVERED WebhookDelivery_State = 2
+	// The event couldn't be delivered and is not retried anymore.
+	WebhookDelivery_STATE_DEAD WebhookDelivery_State = 3
+)
+
+// Enum value maps for WebhookDelivery_State.
+var (
+	WebhookDelivery_State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "STATE_PENDING",
+		2: "STATE_DELIVERED",
+		3: "STATE_DEAD",
+	}
+	WebhookDelivery_State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"STATE_PENDING":     1,
+		"STATE_DELIVERED":   2,
+		"STATE_DEAD":        3,
+	}
+)
+
+func (x WebhookDelivery_State) Enum() *WebhookDelivery_State {
+	p := new(WebhookDelivery_State)
+	*p = x
+	return p
+}
+
+func (x WebhookDelivery_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDelivery_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_service_proto_enumTypes[3].Descriptor()
+}
+
+func (WebhookDelivery_State) Type() protoreflect.EnumType {
+	return &file_tasks_service_proto_enumTypes[3]
+}
+
+func (x WebhookDelivery_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDelivery_State.Descriptor instead.
+func (WebhookDelivery_State) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{69, 0}
+}
+
+type Task_Status int32
+
+const (
+	Task_STATUS_UNSPECIFIED Task_Status = 0
+	Task_STATUS_OPEN        Task_Status = 1
+	Task_STATUS_IN_PROGRESS Task_Status = 2
+	Task_STATUS_BLOCKED     Task_Status = 3
+	Task_STATUS_DONE        Task_Status = 4
+	Task_STATUS_CANCELLED   Task_Status = 5
+)
+
+// Enum value maps for Task_Status.
+var (
+	Task_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_OPEN",
+		2: "STATUS_IN_PROGRESS",
+		3: "STATUS_BLOCKED",
+		4: "STATUS_DONE",
+		5: "STATUS_CANCELLED",
+	}
+	Task_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_OPEN":        1,
+		"STATUS_IN_PROGRESS": 2,
+		"STATUS_BLOCKED":     3,
+		"STATUS_DONE":        4,
+		"STATUS_CANCELLED":   5,
+	}
+)
+
+func (x Task_Status) Enum() *Task_Status {
+	p := new(Task_Status)
+	*p = x
+	return p
+}
+
+func (x Task_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Task_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_service_proto_enumTypes[4].Descriptor()
+}
+
+func (Task_Status) Type() protoreflect.EnumType {
+	return &file_tasks_service_proto_enumTypes[4]
+}
+
+func (x Task_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Task_Status.Descriptor instead.
+func (Task_Status) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{86, 0}
+}
+
+type Task_Priority int32
+
+const (
+	Task_PRIORITY_UNSPECIFIED Task_Priority = 0
+	Task_PRIORITY_LOW         Task_Priority = 1
+	Task_PRIORITY_NORMAL      Task_Priority = 2
+	Task_PRIORITY_HIGH        Task_Priority = 3
+	Task_PRIORITY_URGENT      Task_Priority = 4
+)
+
+// Enum value maps for Task_Priority.
+var (
+	Task_Priority_name = map[int32]string{
+		0: "PRIORITY_UNSPECIFIED",
+		1: "PRIORITY_LOW",
+		2: "PRIORITY_NORMAL",
+		3: "PRIORITY_HIGH",
+		4: "PRIORITY_URGENT",
+	}
+	Task_Priority_value = map[string]int32{
+		"PRIORITY_UNSPECIFIED": 0,
+		"PRIORITY_LOW":         1,
+		"PRIORITY_NORMAL":      2,
+		"PRIORITY_HIGH":        3,
+		"PRIORITY_URGENT":      4,
+	}
+)
+
+func (x Task_Priority) Enum() *Task_Priority {
+	p := new(Task_Priority)
+	*p = x
+	return p
+}
+
+func (x Task_Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Task_Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_service_proto_enumTypes[5].Descriptor()
+}
+
+func (Task_Priority) Type() protoreflect.EnumType {
+	return &file_tasks_service_proto_enumTypes[5]
+}
+
+func (x Task_Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Task_Priority.Descriptor instead.
+func (Task_Priority) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{86, 1}
+}
+
 type GetTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id    int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Also return the task if it was deleted.
+	IncludeDeleted bool `protobuf:"varint,3,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetTaskRequest) Reset() {
@@ -73,6 +417,13 @@ func (x *GetTaskRequest) GetId() int32 {
 	return 0
 }
 
+func (x *GetTaskRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
 type GetTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
@@ -117,30 +468,28 @@ func (x *GetTaskResponse) GetTask() *Task {
 	return nil
 }
 
-type GetTasksIDsRequest struct {
+type GetTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
-	Search        string                 `protobuf:"bytes,4,opt,name=search,proto3" json:"search,omitempty"`
+	Ids           []int32                `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTasksIDsRequest) Reset() {
-	*x = GetTasksIDsRequest{}
+func (x *GetTasksRequest) Reset() {
+	*x = GetTasksRequest{}
 	mi := &file_tasks_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTasksIDsRequest) String() string {
+func (x *GetTasksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTasksIDsRequest) ProtoMessage() {}
+func (*GetTasksRequest) ProtoMessage() {}
 
-func (x *GetTasksIDsRequest) ProtoReflect() protoreflect.Message {
+func (x *GetTasksRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tasks_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -152,61 +501,49 @@ func (x *GetTasksIDsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTasksIDsRequest.ProtoReflect.Descriptor instead.
-func (*GetTasksIDsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetTasksRequest.ProtoReflect.Descriptor instead.
+func (*GetTasksRequest) Descriptor() ([]byte, []int) {
 	return file_tasks_service_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetTasksIDsRequest) GetToken() string {
+func (x *GetTasksRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-func (x *GetTasksIDsRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *GetTasksIDsRequest) GetOffset() int32 {
-	if x != nil {
-		return x.Offset
-	}
-	return 0
-}
-
-func (x *GetTasksIDsRequest) GetSearch() string {
+func (x *GetTasksRequest) GetIds() []int32 {
 	if x != nil {
-		return x.Search
+		return x.Ids
 	}
-	return ""
+	return nil
 }
 
-type GetTasksIDsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
-	Results       []int32                `protobuf:"varint,2,rep,packed,name=results,proto3" json:"results,omitempty"`
+type GetTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Found tasks, in the order of the requested ids.
+	Tasks []*Task `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Requested ids of tasks that don't exist.
+	MissingIds    []int32 `protobuf:"varint,2,rep,packed,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTasksIDsResponse) Reset() {
-	*x = GetTasksIDsResponse{}
+func (x *GetTasksResponse) Reset() {
+	*x = GetTasksResponse{}
 	mi := &file_tasks_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTasksIDsResponse) String() string {
+func (x *GetTasksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTasksIDsResponse) ProtoMessage() {}
+func (*GetTasksResponse) ProtoMessage() {}
 
-func (x *GetTasksIDsResponse) ProtoReflect() protoreflect.Message {
+func (x *GetTasksResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tasks_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -218,50 +555,61 @@ func (x *GetTasksIDsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTasksIDsResponse.ProtoReflect.Descriptor instead.
-func (*GetTasksIDsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetTasksResponse.ProtoReflect.Descriptor instead.
+func (*GetTasksResponse) Descriptor() ([]byte, []int) {
 	return file_tasks_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetTasksIDsResponse) GetCount() int32 {
+func (x *GetTasksResponse) GetTasks() []*Task {
 	if x != nil {
-		return x.Count
+		return x.Tasks
 	}
-	return 0
+	return nil
 }
 
-func (x *GetTasksIDsResponse) GetResults() []int32 {
+func (x *GetTasksResponse) GetMissingIds() []int32 {
 	if x != nil {
-		return x.Results
+		return x.MissingIds
 	}
 	return nil
 }
 
-type CreateTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Expertise     string                 `protobuf:"bytes,4,opt,name=expertise,proto3" json:"expertise,omitempty"`
-	PatientId     int32                  `protobuf:"varint,5,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+type GetTasksIDsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Token  string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Search string                 `protobuf:"bytes,4,opt,name=search,proto3" json:"search,omitempty"`
+	// Only return tasks with a due date in the past that are not done or cancelled.
+	OverdueOnly bool `protobuf:"varint,5,opt,name=overdue_only,json=overdueOnly,proto3" json:"overdue_only,omitempty"`
+	// Only return tasks with the given priority, if set.
+	Priority Task_Priority `protobuf:"varint,6,opt,name=priority,proto3,enum=tasks.Task_Priority" json:"priority,omitempty"`
+	// Only return tasks due in the given range, RFC 3339 timestamps. Both bounds are optional.
+	DueAfter   string      `protobuf:"bytes,7,opt,name=due_after,json=dueAfter,proto3" json:"due_after,omitempty"`
+	DueBefore  string      `protobuf:"bytes,8,opt,name=due_before,json=dueBefore,proto3" json:"due_before,omitempty"`
+	OrderBy    TaskOrderBy `protobuf:"varint,9,opt,name=order_by,json=orderBy,proto3,enum=tasks.TaskOrderBy" json:"order_by,omitempty"`
+	Descending bool        `protobuf:"varint,10,opt,name=descending,proto3" json:"descending,omitempty"`
+	Filter     *TaskFilter `protobuf:"bytes,11,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Token of the page to fetch, returned as next_page_token of the previous page. Can't be used with offset.
+	PageToken     string `protobuf:"bytes,12,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTaskRequest) Reset() {
-	*x = CreateTaskRequest{}
+func (x *GetTasksIDsRequest) Reset() {
+	*x = GetTasksIDsRequest{}
 	mi := &file_tasks_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTaskRequest) String() string {
+func (x *GetTasksIDsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTaskRequest) ProtoMessage() {}
+func (*GetTasksIDsRequest) ProtoMessage() {}
 
-func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+func (x *GetTasksIDsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tasks_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -273,112 +621,205 @@ func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
-func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetTasksIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetTasksIDsRequest) Descriptor() ([]byte, []int) {
 	return file_tasks_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CreateTaskRequest) GetToken() string {
+func (x *GetTasksIDsRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-func (x *CreateTaskRequest) GetTitle() string {
+func (x *GetTasksIDsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Title
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateTaskRequest) GetDescription() string {
+func (x *GetTasksIDsRequest) GetOffset() int32 {
 	if x != nil {
-		return x.Description
+		return x.Offset
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateTaskRequest) GetExpertise() string {
+func (x *GetTasksIDsRequest) GetSearch() string {
 	if x != nil {
-		return x.Expertise
+		return x.Search
 	}
 	return ""
 }
 
-func (x *CreateTaskRequest) GetPatientId() int32 {
+func (x *GetTasksIDsRequest) GetOverdueOnly() bool {
 	if x != nil {
-		return x.PatientId
+		return x.OverdueOnly
 	}
-	return 0
+	return false
 }
 
-type CreateTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetTasksIDsRequest) GetPriority() Task_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Task_PRIORITY_UNSPECIFIED
 }
 
-func (x *CreateTaskResponse) Reset() {
-	*x = CreateTaskResponse{}
-	mi := &file_tasks_service_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *GetTasksIDsRequest) GetDueAfter() string {
+	if x != nil {
+		return x.DueAfter
+	}
+	return ""
 }
 
-func (x *CreateTaskResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetTasksIDsRequest) GetDueBefore() string {
+	if x != nil {
+		return x.DueBefore
+	}
+	return ""
 }
 
-func (*CreateTaskResponse) ProtoMessage() {}
+func (x *GetTasksIDsRequest) GetOrderBy() TaskOrderBy {
+	if x != nil {
+		return x.OrderBy
+	}
+	return TaskOrderBy_TASK_ORDER_BY_UNSPECIFIED
+}
 
-func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tasks_service_proto_msgTypes[5]
+func (x *GetTasksIDsRequest) GetDescending() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Descending
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
-func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
+func (x *GetTasksIDsRequest) GetFilter() *TaskFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GetTasksIDsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// TaskFilter narrows down listed tasks. Unset fields don't filter anything.
+type TaskFilter struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PatientId int32                  `protobuf:"varint,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	Expertise string                 `protobuf:"bytes,2,opt,name=expertise,proto3" json:"expertise,omitempty"`
+	Complete  *bool                  `protobuf:"varint,3,opt,name=complete,proto3,oneof" json:"complete,omitempty"`
+	// Creation time range, RFC 3339 timestamps. Both bounds are optional.
+	CreatedAfter  string `protobuf:"bytes,4,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore string `protobuf:"bytes,5,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	Assignee      string `protobuf:"bytes,6,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskFilter) Reset() {
+	*x = TaskFilter{}
+	mi := &file_tasks_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskFilter) ProtoMessage() {}
+
+func (x *TaskFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskFilter.ProtoReflect.Descriptor instead.
+func (*TaskFilter) Descriptor() ([]byte, []int) {
 	return file_tasks_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CreateTaskResponse) GetId() int32 {
+func (x *TaskFilter) GetPatientId() int32 {
 	if x != nil {
-		return x.Id
+		return x.PatientId
 	}
 	return 0
 }
 
-type DeleteTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+func (x *TaskFilter) GetExpertise() string {
+	if x != nil {
+		return x.Expertise
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetComplete() bool {
+	if x != nil && x.Complete != nil {
+		return *x.Complete
+	}
+	return false
+}
+
+func (x *TaskFilter) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+type GetTasksIDsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Count   int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Results []int32                `protobuf:"varint,2,rep,packed,name=results,proto3" json:"results,omitempty"`
+	// Empty if there are no more results.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTaskRequest) Reset() {
-	*x = DeleteTaskRequest{}
+func (x *GetTasksIDsResponse) Reset() {
+	*x = GetTasksIDsResponse{}
 	mi := &file_tasks_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTaskRequest) String() string {
+func (x *GetTasksIDsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTaskRequest) ProtoMessage() {}
+func (*GetTasksIDsResponse) ProtoMessage() {}
 
-func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+func (x *GetTasksIDsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tasks_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -390,45 +831,63 @@ func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetTasksIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetTasksIDsResponse) Descriptor() ([]byte, []int) {
 	return file_tasks_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *DeleteTaskRequest) GetToken() string {
+func (x *GetTasksIDsResponse) GetCount() int32 {
 	if x != nil {
-		return x.Token
+		return x.Count
 	}
-	return ""
+	return 0
 }
 
-func (x *DeleteTaskRequest) GetId() int32 {
+func (x *GetTasksIDsResponse) GetResults() []int32 {
 	if x != nil {
-		return x.Id
+		return x.Results
 	}
-	return 0
+	return nil
 }
 
-type DeleteTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *GetTasksIDsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CreateTaskRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Token       string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Expertise   string                 `protobuf:"bytes,4,opt,name=expertise,proto3" json:"expertise,omitempty"`
+	// Required. Verified with the patients service when it is configured.
+	PatientId int32 `protobuf:"varint,5,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	// RFC 3339 timestamp, optional.
+	DueAt string `protobuf:"bytes,6,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	// Defaults to PRIORITY_NORMAL.
+	Priority      Task_Priority `protobuf:"varint,7,opt,name=priority,proto3,enum=tasks.Task_Priority" json:"priority,omitempty"`
+	SpecialNote   string        `protobuf:"bytes,8,opt,name=special_note,json=specialNote,proto3" json:"special_note,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTaskResponse) Reset() {
-	*x = DeleteTaskResponse{}
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
 	mi := &file_tasks_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTaskResponse) String() string {
+func (x *CreateTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTaskResponse) ProtoMessage() {}
+func (*CreateTaskRequest) ProtoMessage() {}
 
-func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tasks_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -440,33 +899,88 @@ func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
 	return file_tasks_service_proto_rawDescGZIP(), []int{7}
 }
 
-type UpdateTaskRequest struct {
+func (x *CreateTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetExpertise() string {
+	if x != nil {
+		return x.Expertise
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetPatientId() int32 {
+	if x != nil {
+		return x.PatientId
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetDueAt() string {
+	if x != nil {
+		return x.DueAt
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetPriority() Task_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Task_PRIORITY_UNSPECIFIED
+}
+
+func (x *CreateTaskRequest) GetSpecialNote() string {
+	if x != nil {
+		return x.SpecialNote
+	}
+	return ""
+}
+
+type CreateTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Task          *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTaskRequest) Reset() {
-	*x = UpdateTaskRequest{}
+func (x *CreateTaskResponse) Reset() {
+	*x = CreateTaskResponse{}
 	mi := &file_tasks_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTaskRequest) String() string {
+func (x *CreateTaskResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTaskRequest) ProtoMessage() {}
+func (*CreateTaskResponse) ProtoMessage() {}
 
-func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tasks_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -478,47 +992,4246 @@ func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
+func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
 	return file_tasks_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UpdateTaskRequest) GetToken() string {
+func (x *CreateTaskResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id    int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Current version of the task, required. The task is not deleted if it was changed since.
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_tasks_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteTaskRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-func (x *UpdateTaskRequest) GetTask() *Task {
+func (x *DeleteTaskRequest) GetId() int32 {
 	if x != nil {
-		return x.Task
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type UpdateTaskResponse struct {
+func (x *DeleteTaskRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTaskResponse) Reset() {
-	*x = UpdateTaskResponse{}
-	mi := &file_tasks_service_proto_msgTypes[9]
+func (x *DeleteTaskResponse) Reset() {
+	*x = DeleteTaskResponse{}
+	mi := &file_tasks_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskResponse) ProtoMessage() {}
+
+func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{10}
+}
+
+type RestoreTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTaskRequest) Reset() {
+	*x = RestoreTaskRequest{}
+	mi := &file_tasks_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskRequest) ProtoMessage() {}
+
+func (x *RestoreTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RestoreTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RestoreTaskRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RestoreTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTaskResponse) Reset() {
+	*x = RestoreTaskResponse{}
+	mi := &file_tasks_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskResponse) ProtoMessage() {}
+
+func (x *RestoreTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskResponse.ProtoReflect.Descriptor instead.
+func (*RestoreTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{12}
+}
+
+type PurgeTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeTaskRequest) Reset() {
+	*x = PurgeTaskRequest{}
+	mi := &file_tasks_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeTaskRequest) ProtoMessage() {}
+
+func (x *PurgeTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeTaskRequest.ProtoReflect.Descriptor instead.
+func (*PurgeTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PurgeTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PurgeTaskRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type PurgeTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeTaskResponse) Reset() {
+	*x = PurgeTaskResponse{}
+	mi := &file_tasks_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeTaskResponse) ProtoMessage() {}
+
+func (x *PurgeTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeTaskResponse.ProtoReflect.Descriptor instead.
+func (*PurgeTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{14}
+}
+
+type UpdateTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Task  *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// Fields of the task to update, e.g. "title" or "complete". If empty, the whole task is replaced.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_tasks_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateTaskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Version of the task after the update.
+	Version       int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskResponse) Reset() {
+	*x = UpdateTaskResponse{}
+	mi := &file_tasks_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskResponse) ProtoMessage() {}
+
+func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateTaskResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTaskResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GetTasksByPatientRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Token     string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	PatientId int32                  `protobuf:"varint,2,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	// Maximum number of tasks to return, at most 50. Defaults to 50 if not set.
+	Limit     int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Also return tasks that are done or cancelled. Defaults to true.
+	IncludeCompleted *bool       `protobuf:"varint,5,opt,name=include_completed,json=includeCompleted,proto3,oneof" json:"include_completed,omitempty"`
+	OrderBy          TaskOrderBy `protobuf:"varint,6,opt,name=order_by,json=orderBy,proto3,enum=tasks.TaskOrderBy" json:"order_by,omitempty"`
+	Descending       bool        `protobuf:"varint,7,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetTasksByPatientRequest) Reset() {
+	*x = GetTasksByPatientRequest{}
+	mi := &file_tasks_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTasksByPatientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTasksByPatientRequest) ProtoMessage() {}
+
+func (x *GetTasksByPatientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTasksByPatientRequest.ProtoReflect.Descriptor instead.
+func (*GetTasksByPatientRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetTasksByPatientRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetTasksByPatientRequest) GetPatientId() int32 {
+	if x != nil {
+		return x.PatientId
+	}
+	return 0
+}
+
+func (x *GetTasksByPatientRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetTasksByPatientRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetTasksByPatientRequest) GetIncludeCompleted() bool {
+	if x != nil && x.IncludeCompleted != nil {
+		return *x.IncludeCompleted
+	}
+	return false
+}
+
+func (x *GetTasksByPatientRequest) GetOrderBy() TaskOrderBy {
+	if x != nil {
+		return x.OrderBy
+	}
+	return TaskOrderBy_TASK_ORDER_BY_UNSPECIFIED
+}
+
+func (x *GetTasksByPatientRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+type GetTasksByPatientResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Empty if there are no more results.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Total number of matching tasks of the patient.
+	Count         int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTasksByPatientResponse) Reset() {
+	*x = GetTasksByPatientResponse{}
+	mi := &file_tasks_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTasksByPatientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTasksByPatientResponse) ProtoMessage() {}
+
+func (x *GetTasksByPatientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTasksByPatientResponse.ProtoReflect.Descriptor instead.
+func (*GetTasksByPatientResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetTasksByPatientResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *GetTasksByPatientResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetTasksByPatientResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type AssignTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id    int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Subject of the user to assign. Defaults to the caller.
+	Assignee      string `protobuf:"bytes,3,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignTaskRequest) Reset() {
+	*x = AssignTaskRequest{}
+	mi := &file_tasks_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignTaskRequest) ProtoMessage() {}
+
+func (x *AssignTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignTaskRequest.ProtoReflect.Descriptor instead.
+func (*AssignTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AssignTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AssignTaskRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AssignTaskRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+type AssignTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignTaskResponse) Reset() {
+	*x = AssignTaskResponse{}
+	mi := &file_tasks_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignTaskResponse) ProtoMessage() {}
+
+func (x *AssignTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignTaskResponse.ProtoReflect.Descriptor instead.
+func (*AssignTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{20}
+}
+
+type UnassignTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignTaskRequest) Reset() {
+	*x = UnassignTaskRequest{}
+	mi := &file_tasks_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignTaskRequest) ProtoMessage() {}
+
+func (x *UnassignTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignTaskRequest.ProtoReflect.Descriptor instead.
+func (*UnassignTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UnassignTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *UnassignTaskRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UnassignTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignTaskResponse) Reset() {
+	*x = UnassignTaskResponse{}
+	mi := &file_tasks_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignTaskResponse) ProtoMessage() {}
+
+func (x *UnassignTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignTaskResponse.ProtoReflect.Descriptor instead.
+func (*UnassignTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{22}
+}
+
+type GetMyTasksIDsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Token           string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Limit           int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset          int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	IncludeComplete bool                   `protobuf:"varint,4,opt,name=include_complete,json=includeComplete,proto3" json:"include_complete,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetMyTasksIDsRequest) Reset() {
+	*x = GetMyTasksIDsRequest{}
+	mi := &file_tasks_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyTasksIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyTasksIDsRequest) ProtoMessage() {}
+
+func (x *GetMyTasksIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyTasksIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetMyTasksIDsRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetMyTasksIDsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetMyTasksIDsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetMyTasksIDsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetMyTasksIDsRequest) GetIncludeComplete() bool {
+	if x != nil {
+		return x.IncludeComplete
+	}
+	return false
+}
+
+type GetMyTasksIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Results       []int32                `protobuf:"varint,2,rep,packed,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyTasksIDsResponse) Reset() {
+	*x = GetMyTasksIDsResponse{}
+	mi := &file_tasks_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyTasksIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyTasksIDsResponse) ProtoMessage() {}
+
+func (x *GetMyTasksIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyTasksIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetMyTasksIDsResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetMyTasksIDsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetMyTasksIDsResponse) GetResults() []int32 {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type TransitionTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Status        Task_Status            `protobuf:"varint,3,opt,name=status,proto3,enum=tasks.Task_Status" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransitionTaskRequest) Reset() {
+	*x = TransitionTaskRequest{}
+	mi := &file_tasks_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionTaskRequest) ProtoMessage() {}
+
+func (x *TransitionTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionTaskRequest.ProtoReflect.Descriptor instead.
+func (*TransitionTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TransitionTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *TransitionTaskRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TransitionTaskRequest) GetStatus() Task_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Task_STATUS_UNSPECIFIED
+}
+
+type TransitionTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransitionTaskResponse) Reset() {
+	*x = TransitionTaskResponse{}
+	mi := &file_tasks_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionTaskResponse) ProtoMessage() {}
+
+func (x *TransitionTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionTaskResponse.ProtoReflect.Descriptor instead.
+func (*TransitionTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TransitionTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type GetTaskHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Token  string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TaskId int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Maximum number of entries to return. Defaults to the maximum allowed limit.
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Token of the page to return, as returned by a previous call with the same parameters.
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskHistoryRequest) Reset() {
+	*x = GetTaskHistoryRequest{}
+	mi := &file_tasks_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryRequest) ProtoMessage() {}
+
+func (x *GetTaskHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetTaskHistoryRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetTaskHistoryRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *GetTaskHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetTaskHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetTaskHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest entries first.
+	Entries []*TaskHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Empty if there are no more entries.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskHistoryResponse) Reset() {
+	*x = GetTaskHistoryResponse{}
+	mi := &file_tasks_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryResponse) ProtoMessage() {}
+
+func (x *GetTaskHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetTaskHistoryResponse) GetEntries() []*TaskHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetTaskHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// A recorded change of a task.
+type TaskHistoryEntry struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// One of create, update, delete, restore, purge, archive and unarchive.
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// Subject of the user that made the change.
+	Actor   string             `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	Changes []*TaskFieldChange `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
+	// RFC 3339 timestamp of the change.
+	CreatedAt     string `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskHistoryEntry) Reset() {
+	*x = TaskHistoryEntry{}
+	mi := &file_tasks_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskHistoryEntry) ProtoMessage() {}
+
+func (x *TaskHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskHistoryEntry.ProtoReflect.Descriptor instead.
+func (*TaskHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TaskHistoryEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskHistoryEntry) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskHistoryEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TaskHistoryEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *TaskHistoryEntry) GetChanges() []*TaskFieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *TaskHistoryEntry) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Values of a task field, named as in Task, before and after a change. Unset values are null.
+type TaskFieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Before        *structpb.Value        `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         *structpb.Value        `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskFieldChange) Reset() {
+	*x = TaskFieldChange{}
+	mi := &file_tasks_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskFieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskFieldChange) ProtoMessage() {}
+
+func (x *TaskFieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskFieldChange.ProtoReflect.Descriptor instead.
+func (*TaskFieldChange) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TaskFieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *TaskFieldChange) GetBefore() *structpb.Value {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *TaskFieldChange) GetAfter() *structpb.Value {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+type AddChecklistItemRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Token  string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TaskId int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Title  string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// Items are ordered by position. If not set, the item is added after all the other items.
+	Position      int32 `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddChecklistItemRequest) Reset() {
+	*x = AddChecklistItemRequest{}
+	mi := &file_tasks_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddChecklistItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddChecklistItemRequest) ProtoMessage() {}
+
+func (x *AddChecklistItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddChecklistItemRequest.ProtoReflect.Descriptor instead.
+func (*AddChecklistItemRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AddChecklistItemRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AddChecklistItemRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AddChecklistItemRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AddChecklistItemRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type AddChecklistItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *ChecklistItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddChecklistItemResponse) Reset() {
+	*x = AddChecklistItemResponse{}
+	mi := &file_tasks_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddChecklistItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddChecklistItemResponse) ProtoMessage() {}
+
+func (x *AddChecklistItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddChecklistItemResponse.ProtoReflect.Descriptor instead.
+func (*AddChecklistItemResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AddChecklistItemResponse) GetItem() *ChecklistItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type UpdateChecklistItemRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Item  *ChecklistItem         `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	// Fields of the item to update, one of "title", "done" and "position". If empty, the whole item is replaced.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateChecklistItemRequest) Reset() {
+	*x = UpdateChecklistItemRequest{}
+	mi := &file_tasks_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateChecklistItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateChecklistItemRequest) ProtoMessage() {}
+
+func (x *UpdateChecklistItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateChecklistItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateChecklistItemRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateChecklistItemRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *UpdateChecklistItemRequest) GetItem() *ChecklistItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *UpdateChecklistItemRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateChecklistItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *ChecklistItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateChecklistItemResponse) Reset() {
+	*x = UpdateChecklistItemResponse{}
+	mi := &file_tasks_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateChecklistItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateChecklistItemResponse) ProtoMessage() {}
+
+func (x *UpdateChecklistItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateChecklistItemResponse.ProtoReflect.Descriptor instead.
+func (*UpdateChecklistItemResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateChecklistItemResponse) GetItem() *ChecklistItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type DeleteChecklistItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteChecklistItemRequest) Reset() {
+	*x = DeleteChecklistItemRequest{}
+	mi := &file_tasks_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChecklistItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChecklistItemRequest) ProtoMessage() {}
+
+func (x *DeleteChecklistItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChecklistItemRequest.ProtoReflect.Descriptor instead.
+func (*DeleteChecklistItemRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteChecklistItemRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeleteChecklistItemRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteChecklistItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteChecklistItemResponse) Reset() {
+	*x = DeleteChecklistItemResponse{}
+	mi := &file_tasks_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChecklistItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChecklistItemResponse) ProtoMessage() {}
+
+func (x *DeleteChecklistItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChecklistItemResponse.ProtoReflect.Descriptor instead.
+func (*DeleteChecklistItemResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{36}
+}
+
+type AddDependencyRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Token  string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TaskId int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Id of the task that has to be finished before the task can start.
+	DependsOnId   int32 `protobuf:"varint,3,opt,name=depends_on_id,json=dependsOnId,proto3" json:"depends_on_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDependencyRequest) Reset() {
+	*x = AddDependencyRequest{}
+	mi := &file_tasks_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDependencyRequest) ProtoMessage() {}
+
+func (x *AddDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDependencyRequest.ProtoReflect.Descriptor instead.
+func (*AddDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AddDependencyRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AddDependencyRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AddDependencyRequest) GetDependsOnId() int32 {
+	if x != nil {
+		return x.DependsOnId
+	}
+	return 0
+}
+
+type AddDependencyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDependencyResponse) Reset() {
+	*x = AddDependencyResponse{}
+	mi := &file_tasks_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDependencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDependencyResponse) ProtoMessage() {}
+
+func (x *AddDependencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDependencyResponse.ProtoReflect.Descriptor instead.
+func (*AddDependencyResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{38}
+}
+
+type RemoveDependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	DependsOnId   int32                  `protobuf:"varint,3,opt,name=depends_on_id,json=dependsOnId,proto3" json:"depends_on_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDependencyRequest) Reset() {
+	*x = RemoveDependencyRequest{}
+	mi := &file_tasks_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDependencyRequest) ProtoMessage() {}
+
+func (x *RemoveDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDependencyRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RemoveDependencyRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RemoveDependencyRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *RemoveDependencyRequest) GetDependsOnId() int32 {
+	if x != nil {
+		return x.DependsOnId
+	}
+	return 0
+}
+
+type RemoveDependencyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDependencyResponse) Reset() {
+	*x = RemoveDependencyResponse{}
+	mi := &file_tasks_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDependencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDependencyResponse) ProtoMessage() {}
+
+func (x *RemoveDependencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDependencyResponse.ProtoReflect.Descriptor instead.
+func (*RemoveDependencyResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{40}
+}
+
+type CreateTaskTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Id, next_run_at and created_at are ignored.
+	Template      *TaskTemplate `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskTemplateRequest) Reset() {
+	*x = CreateTaskTemplateRequest{}
+	mi := &file_tasks_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskTemplateRequest) ProtoMessage() {}
+
+func (x *CreateTaskTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreateTaskTemplateRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateTaskTemplateRequest) GetTemplate() *TaskTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type CreateTaskTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskTemplateResponse) Reset() {
+	*x = CreateTaskTemplateResponse{}
+	mi := &file_tasks_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskTemplateResponse) ProtoMessage() {}
+
+func (x *CreateTaskTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreateTaskTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateTaskTemplateResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetTaskTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskTemplateRequest) Reset() {
+	*x = GetTaskTemplateRequest{}
+	mi := &file_tasks_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskTemplateRequest) ProtoMessage() {}
+
+func (x *GetTaskTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetTaskTemplateRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetTaskTemplateRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetTaskTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *TaskTemplate          `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskTemplateResponse) Reset() {
+	*x = GetTaskTemplateResponse{}
+	mi := &file_tasks_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskTemplateResponse) ProtoMessage() {}
+
+func (x *GetTaskTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskTemplateResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetTaskTemplateResponse) GetTemplate() *TaskTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type GetTaskTemplatesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Token  string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Offset int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit  int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// If set, only the templates of the patient are returned.
+	PatientId     int32 `protobuf:"varint,4,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskTemplatesRequest) Reset() {
+	*x = GetTaskTemplatesRequest{}
+	mi := &file_tasks_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskTemplatesRequest) ProtoMessage() {}
+
+func (x *GetTaskTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetTaskTemplatesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetTaskTemplatesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetTaskTemplatesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetTaskTemplatesRequest) GetPatientId() int32 {
+	if x != nil {
+		return x.PatientId
+	}
+	return 0
+}
+
+type GetTaskTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Templates     []*TaskTemplate        `protobuf:"bytes,2,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskTemplatesResponse) Reset() {
+	*x = GetTaskTemplatesResponse{}
+	mi := &file_tasks_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskTemplatesResponse) ProtoMessage() {}
+
+func (x *GetTaskTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetTaskTemplatesResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetTaskTemplatesResponse) GetTemplates() []*TaskTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type DeleteTaskTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskTemplateRequest) Reset() {
+	*x = DeleteTaskTemplateRequest{}
+	mi := &file_tasks_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteTaskTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DeleteTaskTemplateRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeleteTaskTemplateRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteTaskTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskTemplateResponse) Reset() {
+	*x = DeleteTaskTemplateResponse{}
+	mi := &file_tasks_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteTaskTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{48}
+}
+
+type AddCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	mi := &file_tasks_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *AddCommentRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AddCommentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type AddCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentResponse) Reset() {
+	*x = AddCommentResponse{}
+	mi := &file_tasks_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentResponse) ProtoMessage() {}
+
+func (x *AddCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentResponse.ProtoReflect.Descriptor instead.
+func (*AddCommentResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AddCommentResponse) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type ListCommentsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Token  string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TaskId int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Maximum number of comments to return. Defaults to the maximum allowed limit.
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Token of the page to return, as returned by a previous call with the same parameters.
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_tasks_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListCommentsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListCommentsRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListCommentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest comments first.
+	Comments []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	// Empty if there are no more comments.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Total number of comments of the task.
+	Count         int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_tasks_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListCommentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListCommentsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type EditCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditCommentRequest) Reset() {
+	*x = EditCommentRequest{}
+	mi := &file_tasks_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditCommentRequest) ProtoMessage() {}
+
+func (x *EditCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditCommentRequest.ProtoReflect.Descriptor instead.
+func (*EditCommentRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *EditCommentRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *EditCommentRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EditCommentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type EditCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditCommentResponse) Reset() {
+	*x = EditCommentResponse{}
+	mi := &file_tasks_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditCommentResponse) ProtoMessage() {}
+
+func (x *EditCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditCommentResponse.ProtoReflect.Descriptor instead.
+func (*EditCommentResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *EditCommentResponse) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type DeleteCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
+	mi := &file_tasks_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRequest) ProtoMessage() {}
+
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DeleteCommentRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeleteCommentRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentResponse) Reset() {
+	*x = DeleteCommentResponse{}
+	mi := &file_tasks_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentResponse) ProtoMessage() {}
+
+func (x *DeleteCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{56}
+}
+
+// A comment on a task.
+type Comment struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Subject of the user that wrote the comment.
+	Author string `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Body   string `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	// RFC 3339 timestamp.
+	CreatedAt string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// RFC 3339 timestamp of the last edit. Empty if the comment was never edited.
+	EditedAt      string `protobuf:"bytes,6,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_tasks_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Comment) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Comment) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Comment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Comment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Comment) GetEditedAt() string {
+	if x != nil {
+		return x.EditedAt
+	}
+	return ""
+}
+
+type WatchTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Filter of the events. Unset fields match all the tasks.
+	PatientId int32  `protobuf:"varint,2,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	Assignee  string `protobuf:"bytes,3,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Expertise string `protobuf:"bytes,4,opt,name=expertise,proto3" json:"expertise,omitempty"`
+	// Id of the last event the client has seen. If set, the events after it are streamed first.
+	// Events are kept for 7 days, resuming after an older event fails with OUT_OF_RANGE.
+	ResumeAfterId int64 `protobuf:"varint,5,opt,name=resume_after_id,json=resumeAfterId,proto3" json:"resume_after_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksRequest) Reset() {
+	*x = WatchTasksRequest{}
+	mi := &file_tasks_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksRequest) ProtoMessage() {}
+
+func (x *WatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *WatchTasksRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *WatchTasksRequest) GetPatientId() int32 {
+	if x != nil {
+		return x.PatientId
+	}
+	return 0
+}
+
+func (x *WatchTasksRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *WatchTasksRequest) GetExpertise() string {
+	if x != nil {
+		return x.Expertise
+	}
+	return ""
+}
+
+func (x *WatchTasksRequest) GetResumeAfterId() int64 {
+	if x != nil {
+		return x.ResumeAfterId
+	}
+	return 0
+}
+
+// A change of a task.
+type TaskEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique id of the event, send the last seen one as resume_after_id to resume watching.
+	// Events are streamed in the order their transactions started, which is not necessarily the order of their ids.
+	Id     int64          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId int32          `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Type   TaskEvent_Type `protobuf:"varint,3,opt,name=type,proto3,enum=tasks.TaskEvent_Type" json:"type,omitempty"`
+	// RFC 3339 timestamp.
+	CreatedAt     string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_tasks_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *TaskEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetType() TaskEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return TaskEvent_TYPE_UNSPECIFIED
+}
+
+func (x *TaskEvent) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CreateWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// HTTP or HTTPS URL that the events are posted to.
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Types of the events to deliver. Empty means all the types.
+	EventTypes []TaskEvent_Type `protobuf:"varint,3,rep,packed,name=event_types,json=eventTypes,proto3,enum=tasks.TaskEvent_Type" json:"event_types,omitempty"`
+	// Key of the HMAC-SHA256 signatures of the deliveries, at least 16 characters long. It is never returned.
+	Secret        string `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_tasks_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CreateWebhookRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEventTypes() []TaskEvent_Type {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *CreateWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type CreateWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookResponse) Reset() {
+	*x = CreateWebhookResponse{}
+	mi := &file_tasks_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookResponse) ProtoMessage() {}
+
+func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CreateWebhookResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhooksRequest) Reset() {
+	*x = GetWebhooksRequest{}
+	mi := &file_tasks_service_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhooksRequest) ProtoMessage() {}
+
+func (x *GetWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetWebhooksRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GetWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhooksResponse) Reset() {
+	*x = GetWebhooksResponse{}
+	mi := &file_tasks_service_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhooksResponse) ProtoMessage() {}
+
+func (x *GetWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetWebhooksResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_tasks_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *DeleteWebhookRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeleteWebhookRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_tasks_service_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{65}
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Token     string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	WebhookId int32                  `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// If set, only the deliveries in the state are returned. STATE_DEAD lists the dead letters.
+	State WebhookDelivery_State `protobuf:"varint,3,opt,name=state,proto3,enum=tasks.WebhookDelivery_State" json:"state,omitempty"`
+	// Maximum number of deliveries to return. Defaults to the maximum allowed limit.
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Token of the page to return, as returned by a previous call with the same parameters.
+	PageToken     string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_tasks_service_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() int32 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetState() WebhookDelivery_State {
+	if x != nil {
+		return x.State
+	}
+	return WebhookDelivery_STATE_UNSPECIFIED
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest deliveries first.
+	Deliveries []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	// Empty if there are no more deliveries.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_tasks_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// A subscription of an external service to task events.
+type Webhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url   string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Empty means all the types.
+	EventTypes []TaskEvent_Type `protobuf:"varint,3,rep,packed,name=event_types,json=eventTypes,proto3,enum=tasks.TaskEvent_Type" json:"event_types,omitempty"`
+	// Subject of the user that created the webhook.
+	CreatedBy string `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// RFC 3339 timestamp.
+	CreatedAt     string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_tasks_service_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *Webhook) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventTypes() []TaskEvent_Type {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Webhook) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Webhook) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// A delivery of a task event to a webhook.
+type WebhookDelivery struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId int32                  `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// Id of the event, the same for all the webhooks it is delivered to.
+	EventId   int64                 `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType TaskEvent_Type        `protobuf:"varint,4,opt,name=event_type,json=eventType,proto3,enum=tasks.TaskEvent_Type" json:"event_type,omitempty"`
+	TaskId    int32                 `protobuf:"varint,5,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	State     WebhookDelivery_State `protobuf:"varint,6,opt,name=state,proto3,enum=tasks.WebhookDelivery_State" json:"state,omitempty"`
+	Attempts  int32                 `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// Error of the last failed attempt.
+	LastError string `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// HTTP status code of the last response. Zero if there was no response.
+	LastStatusCode int32 `protobuf:"varint,9,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code,omitempty"`
+	// RFC 3339 timestamps.
+	NextAttemptAt string `protobuf:"bytes,10,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	DeliveredAt   string `protobuf:"bytes,11,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	CreatedAt     string `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_tasks_service_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *WebhookDelivery) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetWebhookId() int32 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEventType() TaskEvent_Type {
+	if x != nil {
+		return x.EventType
+	}
+	return TaskEvent_TYPE_UNSPECIFIED
+}
+
+func (x *WebhookDelivery) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetState() WebhookDelivery_State {
+	if x != nil {
+		return x.State
+	}
+	return WebhookDelivery_STATE_UNSPECIFIED
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetLastStatusCode() int32 {
+	if x != nil {
+		return x.LastStatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() string {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() string {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationPreferencesRequest) Reset() {
+	*x = GetNotificationPreferencesRequest{}
+	mi := &file_tasks_service_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *GetNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GetNotificationPreferencesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GetNotificationPreferencesResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Preferences   *NotificationPreferences `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationPreferencesResponse) Reset() {
+	*x = GetNotificationPreferencesResponse{}
+	mi := &file_tasks_service_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationPreferencesResponse) ProtoMessage() {}
+
+func (x *GetNotificationPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetNotificationPreferencesResponse) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Token         string                   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Preferences   *NotificationPreferences `protobuf:"bytes,2,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationPreferencesRequest) Reset() {
+	*x = UpdateNotificationPreferencesRequest{}
+	mi := &file_tasks_service_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *UpdateNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type UpdateNotificationPreferencesResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Preferences   *NotificationPreferences `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationPreferencesResponse) Reset() {
+	*x = UpdateNotificationPreferencesResponse{}
+	mi := &file_tasks_service_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationPreferencesResponse) ProtoMessage() {}
+
+func (x *UpdateNotificationPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *UpdateNotificationPreferencesResponse) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type GetMyNotificationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// If set, only the notifications that were not marked as read are returned.
+	UnreadOnly bool `protobuf:"varint,2,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"`
+	// Maximum number of notifications to return. Defaults to the maximum allowed limit.
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Token of the page to return, as returned by a previous call with the same parameters.
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyNotificationsRequest) Reset() {
+	*x = GetMyNotificationsRequest{}
+	mi := &file_tasks_service_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyNotificationsRequest) ProtoMessage() {}
+
+func (x *GetMyNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*GetMyNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *GetMyNotificationsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetMyNotificationsRequest) GetUnreadOnly() bool {
+	if x != nil {
+		return x.UnreadOnly
+	}
+	return false
+}
+
+func (x *GetMyNotificationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetMyNotificationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetMyNotificationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest notifications first.
+	Notifications []*Notification `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	// Empty if there are no more notifications.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyNotificationsResponse) Reset() {
+	*x = GetMyNotificationsResponse{}
+	mi := &file_tasks_service_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyNotificationsResponse) ProtoMessage() {}
+
+func (x *GetMyNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*GetMyNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetMyNotificationsResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *GetMyNotificationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type MarkNotificationsReadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Ids of the notifications of the caller. Ids of other notifications are ignored.
+	Ids           []int64 `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNotificationsReadRequest) Reset() {
+	*x = MarkNotificationsReadRequest{}
+	mi := &file_tasks_service_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationsReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationsReadRequest) ProtoMessage() {}
+
+func (x *MarkNotificationsReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationsReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkNotificationsReadRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *MarkNotificationsReadRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *MarkNotificationsReadRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type MarkNotificationsReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNotificationsReadResponse) Reset() {
+	*x = MarkNotificationsReadResponse{}
+	mi := &file_tasks_service_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationsReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationsReadResponse) ProtoMessage() {}
+
+func (x *MarkNotificationsReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationsReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkNotificationsReadResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{77}
+}
+
+type ArchivePatientTasksRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Token     string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	PatientId int32                  `protobuf:"varint,2,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	// Why the tasks are archived, e.g. "patient was deleted".
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchivePatientTasksRequest) Reset() {
+	*x = ArchivePatientTasksRequest{}
+	mi := &file_tasks_service_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchivePatientTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchivePatientTasksRequest) ProtoMessage() {}
+
+func (x *ArchivePatientTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchivePatientTasksRequest.ProtoReflect.Descriptor instead.
+func (*ArchivePatientTasksRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ArchivePatientTasksRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ArchivePatientTasksRequest) GetPatientId() int32 {
+	if x != nil {
+		return x.PatientId
+	}
+	return 0
+}
+
+func (x *ArchivePatientTasksRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ArchivePatientTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ids of the tasks that were cancelled.
+	Ids           []int32 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchivePatientTasksResponse) Reset() {
+	*x = ArchivePatientTasksResponse{}
+	mi := &file_tasks_service_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchivePatientTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchivePatientTasksResponse) ProtoMessage() {}
+
+func (x *ArchivePatientTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchivePatientTasksResponse.ProtoReflect.Descriptor instead.
+func (*ArchivePatientTasksResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ArchivePatientTasksResponse) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type RestorePatientTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	PatientId     int32                  `protobuf:"varint,2,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePatientTasksRequest) Reset() {
+	*x = RestorePatientTasksRequest{}
+	mi := &file_tasks_service_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePatientTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePatientTasksRequest) ProtoMessage() {}
+
+func (x *RestorePatientTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePatientTasksRequest.ProtoReflect.Descriptor instead.
+func (*RestorePatientTasksRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *RestorePatientTasksRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RestorePatientTasksRequest) GetPatientId() int32 {
+	if x != nil {
+		return x.PatientId
+	}
+	return 0
+}
+
+type RestorePatientTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ids of the tasks that were moved back to the statuses they had before they were archived.
+	Ids           []int32 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePatientTasksResponse) Reset() {
+	*x = RestorePatientTasksResponse{}
+	mi := &file_tasks_service_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePatientTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePatientTasksResponse) ProtoMessage() {}
+
+func (x *RestorePatientTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePatientTasksResponse.ProtoReflect.Descriptor instead.
+func (*RestorePatientTasksResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *RestorePatientTasksResponse) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// How a user wants to be notified. Users that never set their preferences get in-app notifications only.
+type NotificationPreferences struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty means the user is never notified.
+	Channels []NotificationChannel `protobuf:"varint,1,rep,packed,name=channels,proto3,enum=tasks.NotificationChannel" json:"channels,omitempty"`
+	// Required for NOTIFICATION_CHANNEL_EMAIL.
+	Email string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// E.164 phone number, e.g. +972501234567. Required for NOTIFICATION_CHANNEL_SMS.
+	Phone string `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	// How long before the due date of a task its assignee is reminded, at most a week. Zero means 24 hours.
+	RemindBeforeMinutes int32 `protobuf:"varint,4,opt,name=remind_before_minutes,json=remindBeforeMinutes,proto3" json:"remind_before_minutes,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *NotificationPreferences) Reset() {
+	*x = NotificationPreferences{}
+	mi := &file_tasks_service_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferences) ProtoMessage() {}
+
+func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferences.ProtoReflect.Descriptor instead.
+func (*NotificationPreferences) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *NotificationPreferences) GetChannels() []NotificationChannel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *NotificationPreferences) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetRemindBeforeMinutes() int32 {
+	if x != nil {
+		return x.RemindBeforeMinutes
+	}
+	return 0
+}
+
+// An in-app notification.
+type Notification struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId  int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Message string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Read    bool                   `protobuf:"varint,4,opt,name=read,proto3" json:"read,omitempty"`
+	// RFC 3339 timestamp.
+	CreatedAt     string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_tasks_service_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *Notification) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Notification) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Notification) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Notification) GetRead() bool {
+	if x != nil {
+		return x.Read
+	}
+	return false
+}
+
+func (x *Notification) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// A template of a recurring task. A task is created from the template on every occurrence.
+type TaskTemplate struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Expertise   string                 `protobuf:"bytes,4,opt,name=expertise,proto3" json:"expertise,omitempty"`
+	PatientId   int32                  `protobuf:"varint,5,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	SpecialNote string                 `protobuf:"bytes,6,opt,name=special_note,json=specialNote,proto3" json:"special_note,omitempty"`
+	// Priority of the created tasks. Defaults to PRIORITY_NORMAL.
+	Priority Task_Priority `protobuf:"varint,7,opt,name=priority,proto3,enum=tasks.Task_Priority" json:"priority,omitempty"`
+	// Subset of RFC 5545 recurrence rules, e.g. "FREQ=WEEKLY;INTERVAL=2".
+	// FREQ is one of DAILY, WEEKLY, MONTHLY and YEARLY, INTERVAL defaults to 1.
+	Recurrence string `protobuf:"bytes,8,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// RFC 3339 timestamp of the first occurrence. Defaults to the creation time.
+	StartsAt string `protobuf:"bytes,9,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	// Due date of the created tasks, in hours after the occurrence. If zero, the tasks have no due date.
+	DueInHours int32 `protobuf:"varint,10,opt,name=due_in_hours,json=dueInHours,proto3" json:"due_in_hours,omitempty"`
+	// RFC 3339 timestamp of the next occurrence.
+	NextRunAt string `protobuf:"bytes,11,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	// RFC 3339 timestamp.
+	CreatedAt     string `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskTemplate) Reset() {
+	*x = TaskTemplate{}
+	mi := &file_tasks_service_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTaskResponse) String() string {
+func (x *TaskTemplate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTaskResponse) ProtoMessage() {}
+func (*TaskTemplate) ProtoMessage() {}
 
-func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tasks_service_proto_msgTypes[9]
+func (x *TaskTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -529,92 +5242,122 @@ func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
-func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
-	return file_tasks_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use TaskTemplate.ProtoReflect.Descriptor instead.
+func (*TaskTemplate) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *UpdateTaskResponse) GetId() int32 {
+func (x *TaskTemplate) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type GetTasksByPatientRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	PatientId     int32                  `protobuf:"varint,2,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *TaskTemplate) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
 }
 
-func (x *GetTasksByPatientRequest) Reset() {
-	*x = GetTasksByPatientRequest{}
-	mi := &file_tasks_service_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *TaskTemplate) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
-func (x *GetTasksByPatientRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TaskTemplate) GetExpertise() string {
+	if x != nil {
+		return x.Expertise
+	}
+	return ""
 }
 
-func (*GetTasksByPatientRequest) ProtoMessage() {}
+func (x *TaskTemplate) GetPatientId() int32 {
+	if x != nil {
+		return x.PatientId
+	}
+	return 0
+}
 
-func (x *GetTasksByPatientRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tasks_service_proto_msgTypes[10]
+func (x *TaskTemplate) GetSpecialNote() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.SpecialNote
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetTasksByPatientRequest.ProtoReflect.Descriptor instead.
-func (*GetTasksByPatientRequest) Descriptor() ([]byte, []int) {
-	return file_tasks_service_proto_rawDescGZIP(), []int{10}
+func (x *TaskTemplate) GetPriority() Task_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Task_PRIORITY_UNSPECIFIED
 }
 
-func (x *GetTasksByPatientRequest) GetToken() string {
+func (x *TaskTemplate) GetRecurrence() string {
 	if x != nil {
-		return x.Token
+		return x.Recurrence
 	}
 	return ""
 }
 
-func (x *GetTasksByPatientRequest) GetPatientId() int32 {
+func (x *TaskTemplate) GetStartsAt() string {
 	if x != nil {
-		return x.PatientId
+		return x.StartsAt
+	}
+	return ""
+}
+
+func (x *TaskTemplate) GetDueInHours() int32 {
+	if x != nil {
+		return x.DueInHours
 	}
 	return 0
 }
 
-type GetTasksByPatientResponse struct {
+func (x *TaskTemplate) GetNextRunAt() string {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return ""
+}
+
+func (x *TaskTemplate) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// A step of a task.
+type ChecklistItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Done          bool                   `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	Position      int32                  `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTasksByPatientResponse) Reset() {
-	*x = GetTasksByPatientResponse{}
-	mi := &file_tasks_service_proto_msgTypes[11]
+func (x *ChecklistItem) Reset() {
+	*x = ChecklistItem{}
+	mi := &file_tasks_service_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTasksByPatientResponse) String() string {
+func (x *ChecklistItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTasksByPatientResponse) ProtoMessage() {}
+func (*ChecklistItem) ProtoMessage() {}
 
-func (x *GetTasksByPatientResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tasks_service_proto_msgTypes[11]
+func (x *ChecklistItem) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_service_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -625,34 +5368,89 @@ func (x *GetTasksByPatientResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTasksByPatientResponse.ProtoReflect.Descriptor instead.
-func (*GetTasksByPatientResponse) Descriptor() ([]byte, []int) {
-	return file_tasks_service_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ChecklistItem.ProtoReflect.Descriptor instead.
+func (*ChecklistItem) Descriptor() ([]byte, []int) {
+	return file_tasks_service_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *GetTasksByPatientResponse) GetTasks() []*Task {
+func (x *ChecklistItem) GetId() int32 {
 	if x != nil {
-		return x.Tasks
+		return x.Id
 	}
-	return nil
+	return 0
+}
+
+func (x *ChecklistItem) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ChecklistItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ChecklistItem) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *ChecklistItem) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
 }
 
 type Task struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Complete      bool                   `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
-	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Expertise     string                 `protobuf:"bytes,5,opt,name=expertise,proto3" json:"expertise,omitempty"`
-	PatientId     int32                  `protobuf:"varint,6,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Complete    bool                   `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
+	Title       string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Expertise   string                 `protobuf:"bytes,5,opt,name=expertise,proto3" json:"expertise,omitempty"`
+	PatientId   int32                  `protobuf:"varint,6,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	CreatedAt   string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Assignee    string                 `protobuf:"bytes,8,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	AssignedAt  string                 `protobuf:"bytes,9,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	// Lifecycle status of the task. complete is true if and only if the status is STATUS_DONE.
+	Status Task_Status `protobuf:"varint,10,opt,name=status,proto3,enum=tasks.Task_Status" json:"status,omitempty"`
+	// RFC 3339 timestamp of the last status change and the subject of the user who made it.
+	StatusChangedAt string `protobuf:"bytes,11,opt,name=status_changed_at,json=statusChangedAt,proto3" json:"status_changed_at,omitempty"`
+	StatusChangedBy string `protobuf:"bytes,12,opt,name=status_changed_by,json=statusChangedBy,proto3" json:"status_changed_by,omitempty"`
+	// RFC 3339 timestamp of the due date. Empty if the task has no due date.
+	DueAt    string        `protobuf:"bytes,13,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Priority Task_Priority `protobuf:"varint,14,opt,name=priority,proto3,enum=tasks.Task_Priority" json:"priority,omitempty"`
+	// RFC 3339 timestamp of the deletion. Empty if the task is not deleted.
+	DeletedAt   string `protobuf:"bytes,15,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	SpecialNote string `protobuf:"bytes,16,opt,name=special_note,json=specialNote,proto3" json:"special_note,omitempty"`
+	// Incremented on every change of the task. Required by UpdateTask to detect concurrent changes.
+	Version int64 `protobuf:"varint,17,opt,name=version,proto3" json:"version,omitempty"`
+	// Steps of the task, ordered by position.
+	Checklist []*ChecklistItem `protobuf:"bytes,18,rep,name=checklist,proto3" json:"checklist,omitempty"`
+	// Percentage of done checklist items. Without a checklist, 100 if the task is complete and 0 otherwise.
+	Progress int32 `protobuf:"varint,19,opt,name=progress,proto3" json:"progress,omitempty"`
+	// Ids of the tasks that have to be finished before the task can start. Returned by GetTask only.
+	DependsOn []int32 `protobuf:"varint,20,rep,packed,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	// Ids of the prerequisites that are not finished yet. Returned by GetTask only.
+	BlockedBy []int32 `protobuf:"varint,21,rep,packed,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`
+	// Id of the template the task was created from. Zero if the task was created directly.
+	TemplateId int32 `protobuf:"varint,22,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	// Number of comments on the task.
+	CommentCount  int32 `protobuf:"varint,23,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Task) Reset() {
 	*x = Task{}
-	mi := &file_tasks_service_proto_msgTypes[12]
+	mi := &file_tasks_service_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -664,7 +5462,7 @@ func (x *Task) String() string {
 func (*Task) ProtoMessage() {}
 
 func (x *Task) ProtoReflect() protoreflect.Message {
-	mi := &file_tasks_service_proto_msgTypes[12]
+	mi := &file_tasks_service_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -677,7 +5475,7 @@ func (x *Task) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Task.ProtoReflect.Descriptor instead.
 func (*Task) Descriptor() ([]byte, []int) {
-	return file_tasks_service_proto_rawDescGZIP(), []int{12}
+	return file_tasks_service_proto_rawDescGZIP(), []int{86}
 }
 
 func (x *Task) GetId() int32 {
@@ -729,48 +5527,503 @@ func (x *Task) GetCreatedAt() string {
 	return ""
 }
 
+func (x *Task) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *Task) GetAssignedAt() string {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return ""
+}
+
+func (x *Task) GetStatus() Task_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Task_STATUS_UNSPECIFIED
+}
+
+func (x *Task) GetStatusChangedAt() string {
+	if x != nil {
+		return x.StatusChangedAt
+	}
+	return ""
+}
+
+func (x *Task) GetStatusChangedBy() string {
+	if x != nil {
+		return x.StatusChangedBy
+	}
+	return ""
+}
+
+func (x *Task) GetDueAt() string {
+	if x != nil {
+		return x.DueAt
+	}
+	return ""
+}
+
+func (x *Task) GetPriority() Task_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Task_PRIORITY_UNSPECIFIED
+}
+
+func (x *Task) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *Task) GetSpecialNote() string {
+	if x != nil {
+		return x.SpecialNote
+	}
+	return ""
+}
+
+func (x *Task) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Task) GetChecklist() []*ChecklistItem {
+	if x != nil {
+		return x.Checklist
+	}
+	return nil
+}
+
+func (x *Task) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *Task) GetDependsOn() []int32 {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *Task) GetBlockedBy() []int32 {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return nil
+}
+
+func (x *Task) GetTemplateId() int32 {
+	if x != nil {
+		return x.TemplateId
+	}
+	return 0
+}
+
+func (x *Task) GetCommentCount() int32 {
+	if x != nil {
+		return x.CommentCount
+	}
+	return 0
+}
+
 var File_tasks_service_proto protoreflect.FileDescriptor
 
 const file_tasks_service_proto_rawDesc = "" +
 	"\n" +
-	"\x13tasks_service.proto\x12\x05tasks\"6\n" +
+	"\x13tasks_service.proto\x12\x05tasks\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\"_\n" +
 	"\x0eGetTaskRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\x05R\x02id\"2\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\x12'\n" +
+	"\x0finclude_deleted\x18\x03 \x01(\bR\x0eincludeDeleted\"2\n" +
 	"\x0fGetTaskResponse\x12\x1f\n" +
-	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"p\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"9\n" +
+	"\x0fGetTasksRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\x05R\x03ids\"V\n" +
+	"\x10GetTasksResponse\x12!\n" +
+	"\x05tasks\x18\x01 \x03(\v2\v.tasks.TaskR\x05tasks\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\x05R\n" +
+	"missingIds\"\x9a\x03\n" +
 	"\x12GetTasksIDsRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
-	"\x06search\x18\x04 \x01(\tR\x06search\"E\n" +
+	"\x06search\x18\x04 \x01(\tR\x06search\x12!\n" +
+	"\foverdue_only\x18\x05 \x01(\bR\voverdueOnly\x120\n" +
+	"\bpriority\x18\x06 \x01(\x0e2\x14.tasks.Task.PriorityR\bpriority\x12\x1b\n" +
+	"\tdue_after\x18\a \x01(\tR\bdueAfter\x12\x1d\n" +
+	"\n" +
+	"due_before\x18\b \x01(\tR\tdueBefore\x12-\n" +
+	"\border_by\x18\t \x01(\x0e2\x12.tasks.TaskOrderByR\aorderBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\n" +
+	" \x01(\bR\n" +
+	"descending\x12)\n" +
+	"\x06filter\x18\v \x01(\v2\x11.tasks.TaskFilterR\x06filter\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\f \x01(\tR\tpageToken\"\xdf\x01\n" +
+	"\n" +
+	"TaskFilter\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x01 \x01(\x05R\tpatientId\x12\x1c\n" +
+	"\texpertise\x18\x02 \x01(\tR\texpertise\x12\x1f\n" +
+	"\bcomplete\x18\x03 \x01(\bH\x00R\bcomplete\x88\x01\x01\x12#\n" +
+	"\rcreated_after\x18\x04 \x01(\tR\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\x05 \x01(\tR\rcreatedBefore\x12\x1a\n" +
+	"\bassignee\x18\x06 \x01(\tR\bassigneeB\v\n" +
+	"\t_complete\"m\n" +
 	"\x13GetTasksIDsResponse\x12\x14\n" +
 	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x18\n" +
-	"\aresults\x18\x02 \x03(\x05R\aresults\"\x9e\x01\n" +
+	"\aresults\x18\x02 \x03(\x05R\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\x8a\x02\n" +
 	"\x11CreateTaskRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1c\n" +
 	"\texpertise\x18\x04 \x01(\tR\texpertise\x12\x1d\n" +
 	"\n" +
-	"patient_id\x18\x05 \x01(\x05R\tpatientId\"$\n" +
+	"patient_id\x18\x05 \x01(\x05R\tpatientId\x12\x15\n" +
+	"\x06due_at\x18\x06 \x01(\tR\x05dueAt\x120\n" +
+	"\bpriority\x18\a \x01(\x0e2\x14.tasks.Task.PriorityR\bpriority\x12!\n" +
+	"\fspecial_note\x18\b \x01(\tR\vspecialNote\"$\n" +
 	"\x12CreateTaskResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"9\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"S\n" +
 	"\x11DeleteTaskRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\x05R\x02id\"\x14\n" +
-	"\x12DeleteTaskResponse\"J\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"\x14\n" +
+	"\x12DeleteTaskResponse\":\n" +
+	"\x12RestoreTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"\x15\n" +
+	"\x13RestoreTaskResponse\"8\n" +
+	"\x10PurgeTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"\x13\n" +
+	"\x11PurgeTaskResponse\"\x87\x01\n" +
 	"\x11UpdateTaskRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1f\n" +
-	"\x04task\x18\x02 \x01(\v2\v.tasks.TaskR\x04task\"$\n" +
+	"\x04task\x18\x02 \x01(\v2\v.tasks.TaskR\x04task\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\">\n" +
 	"\x12UpdateTaskResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"O\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"\x9b\x02\n" +
 	"\x18GetTasksByPatientRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
 	"\n" +
-	"patient_id\x18\x02 \x01(\x05R\tpatientId\">\n" +
+	"patient_id\x18\x02 \x01(\x05R\tpatientId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x120\n" +
+	"\x11include_completed\x18\x05 \x01(\bH\x00R\x10includeCompleted\x88\x01\x01\x12-\n" +
+	"\border_by\x18\x06 \x01(\x0e2\x12.tasks.TaskOrderByR\aorderBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\a \x01(\bR\n" +
+	"descendingB\x14\n" +
+	"\x12_include_completed\"|\n" +
 	"\x19GetTasksByPatientResponse\x12!\n" +
-	"\x05tasks\x18\x01 \x03(\v2\v.tasks.TaskR\x05tasks\"\xc6\x01\n" +
+	"\x05tasks\x18\x01 \x03(\v2\v.tasks.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"U\n" +
+	"\x11AssignTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bassignee\x18\x03 \x01(\tR\bassignee\"\x14\n" +
+	"\x12AssignTaskResponse\";\n" +
+	"\x13UnassignTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"\x16\n" +
+	"\x14UnassignTaskResponse\"\x85\x01\n" +
+	"\x14GetMyTasksIDsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12)\n" +
+	"\x10include_complete\x18\x04 \x01(\bR\x0fincludeComplete\"G\n" +
+	"\x15GetMyTasksIDsResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x18\n" +
+	"\aresults\x18\x02 \x03(\x05R\aresults\"i\n" +
+	"\x15TransitionTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\x12*\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x12.tasks.Task.StatusR\x06status\"9\n" +
+	"\x16TransitionTaskResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"{\n" +
+	"\x15GetTaskHistoryRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"s\n" +
+	"\x16GetTaskHistoryResponse\x121\n" +
+	"\aentries\x18\x01 \x03(\v2\x17.tasks.TaskHistoryEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xba\x01\n" +
+	"\x10TaskHistoryEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x120\n" +
+	"\achanges\x18\x05 \x03(\v2\x16.tasks.TaskFieldChangeR\achanges\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"\x85\x01\n" +
+	"\x0fTaskFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12.\n" +
+	"\x06before\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x06before\x12,\n" +
+	"\x05after\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x05after\"z\n" +
+	"\x17AddChecklistItemRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\"D\n" +
+	"\x18AddChecklistItemResponse\x12(\n" +
+	"\x04item\x18\x01 \x01(\v2\x14.tasks.ChecklistItemR\x04item\"\x99\x01\n" +
+	"\x1aUpdateChecklistItemRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\x04item\x18\x02 \x01(\v2\x14.tasks.ChecklistItemR\x04item\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"G\n" +
+	"\x1bUpdateChecklistItemResponse\x12(\n" +
+	"\x04item\x18\x01 \x01(\v2\x14.tasks.ChecklistItemR\x04item\"B\n" +
+	"\x1aDeleteChecklistItemRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"\x1d\n" +
+	"\x1bDeleteChecklistItemResponse\"i\n" +
+	"\x14AddDependencyRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\"\n" +
+	"\rdepends_on_id\x18\x03 \x01(\x05R\vdependsOnId\"\x17\n" +
+	"\x15AddDependencyResponse\"l\n" +
+	"\x17RemoveDependencyRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\"\n" +
+	"\rdepends_on_id\x18\x03 \x01(\x05R\vdependsOnId\"\x1a\n" +
+	"\x18RemoveDependencyResponse\"b\n" +
+	"\x19CreateTaskTemplateRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12/\n" +
+	"\btemplate\x18\x02 \x01(\v2\x13.tasks.TaskTemplateR\btemplate\",\n" +
+	"\x1aCreateTaskTemplateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\">\n" +
+	"\x16GetTaskTemplateRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"J\n" +
+	"\x17GetTaskTemplateResponse\x12/\n" +
+	"\btemplate\x18\x01 \x01(\v2\x13.tasks.TaskTemplateR\btemplate\"|\n" +
+	"\x17GetTaskTemplatesRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x04 \x01(\x05R\tpatientId\"c\n" +
+	"\x18GetTaskTemplatesResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x121\n" +
+	"\ttemplates\x18\x02 \x03(\v2\x13.tasks.TaskTemplateR\ttemplates\"A\n" +
+	"\x19DeleteTaskTemplateRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"\x1c\n" +
+	"\x1aDeleteTaskTemplateResponse\"V\n" +
+	"\x11AddCommentRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\">\n" +
+	"\x12AddCommentResponse\x12(\n" +
+	"\acomment\x18\x01 \x01(\v2\x0e.tasks.CommentR\acomment\"y\n" +
+	"\x13ListCommentsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\x80\x01\n" +
+	"\x14ListCommentsResponse\x12*\n" +
+	"\bcomments\x18\x01 \x03(\v2\x0e.tasks.CommentR\bcomments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"N\n" +
+	"\x12EditCommentRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"?\n" +
+	"\x13EditCommentResponse\x12(\n" +
+	"\acomment\x18\x01 \x01(\v2\x0e.tasks.CommentR\acomment\"<\n" +
+	"\x14DeleteCommentRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"\x17\n" +
+	"\x15DeleteCommentResponse\"\x9a\x01\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1b\n" +
+	"\tedited_at\x18\x06 \x01(\tR\beditedAt\"\xaa\x01\n" +
+	"\x11WatchTasksRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x02 \x01(\x05R\tpatientId\x12\x1a\n" +
+	"\bassignee\x18\x03 \x01(\tR\bassignee\x12\x1c\n" +
+	"\texpertise\x18\x04 \x01(\tR\texpertise\x12&\n" +
+	"\x0fresume_after_id\x18\x05 \x01(\x03R\rresumeAfterId\"\x8d\x02\n" +
+	"\tTaskEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12)\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x15.tasks.TaskEvent.TypeR\x04type\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"\x8c\x01\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fTYPE_CREATED\x10\x01\x12\x10\n" +
+	"\fTYPE_UPDATED\x10\x02\x12\x12\n" +
+	"\x0eTYPE_COMPLETED\x10\x03\x12\x10\n" +
+	"\fTYPE_DELETED\x10\x04\x12\x11\n" +
+	"\rTYPE_RESTORED\x10\x05\x12\x11\n" +
+	"\rTYPE_ASSIGNED\x10\x06\"\x8e\x01\n" +
+	"\x14CreateWebhookRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x126\n" +
+	"\vevent_types\x18\x03 \x03(\x0e2\x15.tasks.TaskEvent.TypeR\n" +
+	"eventTypes\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\"'\n" +
+	"\x15CreateWebhookResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"*\n" +
+	"\x12GetWebhooksRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"A\n" +
+	"\x13GetWebhooksResponse\x12*\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x0e.tasks.WebhookR\bwebhooks\"<\n" +
+	"\x14DeleteWebhookRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"\x17\n" +
+	"\x15DeleteWebhookResponse\"\xbc\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\x05R\twebhookId\x122\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x1c.tasks.WebhookDelivery.StateR\x05state\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"\x7f\n" +
+	"\x1dListWebhookDeliveriesResponse\x126\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x16.tasks.WebhookDeliveryR\n" +
+	"deliveries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa1\x01\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x126\n" +
+	"\vevent_types\x18\x03 \x03(\x0e2\x15.tasks.TaskEvent.TypeR\n" +
+	"eventTypes\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"\x85\x04\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\x05R\twebhookId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\x03R\aeventId\x124\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\x0e2\x15.tasks.TaskEvent.TypeR\teventType\x12\x17\n" +
+	"\atask_id\x18\x05 \x01(\x05R\x06taskId\x122\n" +
+	"\x05state\x18\x06 \x01(\x0e2\x1c.tasks.WebhookDelivery.StateR\x05state\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12(\n" +
+	"\x10last_status_code\x18\t \x01(\x05R\x0elastStatusCode\x12&\n" +
+	"\x0fnext_attempt_at\x18\n" +
+	" \x01(\tR\rnextAttemptAt\x12!\n" +
+	"\fdelivered_at\x18\v \x01(\tR\vdeliveredAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\"V\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATE_PENDING\x10\x01\x12\x13\n" +
+	"\x0fSTATE_DELIVERED\x10\x02\x12\x0e\n" +
+	"\n" +
+	"STATE_DEAD\x10\x03\"9\n" +
+	"!GetNotificationPreferencesRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"f\n" +
+	"\"GetNotificationPreferencesResponse\x12@\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x1e.tasks.NotificationPreferencesR\vpreferences\"~\n" +
+	"$UpdateNotificationPreferencesRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12@\n" +
+	"\vpreferences\x18\x02 \x01(\v2\x1e.tasks.NotificationPreferencesR\vpreferences\"i\n" +
+	"%UpdateNotificationPreferencesResponse\x12@\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x1e.tasks.NotificationPreferencesR\vpreferences\"\x87\x01\n" +
+	"\x19GetMyNotificationsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1f\n" +
+	"\vunread_only\x18\x02 \x01(\bR\n" +
+	"unreadOnly\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\x7f\n" +
+	"\x1aGetMyNotificationsResponse\x129\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x13.tasks.NotificationR\rnotifications\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"F\n" +
+	"\x1cMarkNotificationsReadRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\x03R\x03ids\"\x1f\n" +
+	"\x1dMarkNotificationsReadResponse\"i\n" +
+	"\x1aArchivePatientTasksRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x02 \x01(\x05R\tpatientId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"/\n" +
+	"\x1bArchivePatientTasksResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x05R\x03ids\"Q\n" +
+	"\x1aRestorePatientTasksRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x02 \x01(\x05R\tpatientId\"/\n" +
+	"\x1bRestorePatientTasksResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x05R\x03ids\"\xb1\x01\n" +
+	"\x17NotificationPreferences\x126\n" +
+	"\bchannels\x18\x01 \x03(\x0e2\x1a.tasks.NotificationChannelR\bchannels\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x122\n" +
+	"\x15remind_before_minutes\x18\x04 \x01(\x05R\x13remindBeforeMinutes\"\x84\x01\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x12\n" +
+	"\x04read\x18\x04 \x01(\bR\x04read\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"\x86\x03\n" +
+	"\fTaskTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1c\n" +
+	"\texpertise\x18\x04 \x01(\tR\texpertise\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x05 \x01(\x05R\tpatientId\x12!\n" +
+	"\fspecial_note\x18\x06 \x01(\tR\vspecialNote\x120\n" +
+	"\bpriority\x18\a \x01(\x0e2\x14.tasks.Task.PriorityR\bpriority\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\b \x01(\tR\n" +
+	"recurrence\x12\x1b\n" +
+	"\tstarts_at\x18\t \x01(\tR\bstartsAt\x12 \n" +
+	"\fdue_in_hours\x18\n" +
+	" \x01(\x05R\n" +
+	"dueInHours\x12\x1e\n" +
+	"\vnext_run_at\x18\v \x01(\tR\tnextRunAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\"~\n" +
+	"\rChecklistItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04done\x18\x04 \x01(\bR\x04done\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition\"\xfc\a\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
 	"\bcomplete\x18\x02 \x01(\bR\bcomplete\x12\x14\n" +
@@ -780,17 +6033,99 @@ const file_tasks_service_proto_rawDesc = "" +
 	"\n" +
 	"patient_id\x18\x06 \x01(\x05R\tpatientId\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\a \x01(\tR\tcreatedAt2\xaf\x03\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1a\n" +
+	"\bassignee\x18\b \x01(\tR\bassignee\x12\x1f\n" +
+	"\vassigned_at\x18\t \x01(\tR\n" +
+	"assignedAt\x12*\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x12.tasks.Task.StatusR\x06status\x12*\n" +
+	"\x11status_changed_at\x18\v \x01(\tR\x0fstatusChangedAt\x12*\n" +
+	"\x11status_changed_by\x18\f \x01(\tR\x0fstatusChangedBy\x12\x15\n" +
+	"\x06due_at\x18\r \x01(\tR\x05dueAt\x120\n" +
+	"\bpriority\x18\x0e \x01(\x0e2\x14.tasks.Task.PriorityR\bpriority\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x0f \x01(\tR\tdeletedAt\x12!\n" +
+	"\fspecial_note\x18\x10 \x01(\tR\vspecialNote\x12\x18\n" +
+	"\aversion\x18\x11 \x01(\x03R\aversion\x122\n" +
+	"\tchecklist\x18\x12 \x03(\v2\x14.tasks.ChecklistItemR\tchecklist\x12\x1a\n" +
+	"\bprogress\x18\x13 \x01(\x05R\bprogress\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x14 \x03(\x05R\tdependsOn\x12\x1d\n" +
+	"\n" +
+	"blocked_by\x18\x15 \x03(\x05R\tblockedBy\x12\x1f\n" +
+	"\vtemplate_id\x18\x16 \x01(\x05R\n" +
+	"templateId\x12#\n" +
+	"\rcomment_count\x18\x17 \x01(\x05R\fcommentCount\"\x84\x01\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vSTATUS_OPEN\x10\x01\x12\x16\n" +
+	"\x12STATUS_IN_PROGRESS\x10\x02\x12\x12\n" +
+	"\x0eSTATUS_BLOCKED\x10\x03\x12\x0f\n" +
+	"\vSTATUS_DONE\x10\x04\x12\x14\n" +
+	"\x10STATUS_CANCELLED\x10\x05\"s\n" +
+	"\bPriority\x12\x18\n" +
+	"\x14PRIORITY_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fPRIORITY_LOW\x10\x01\x12\x13\n" +
+	"\x0fPRIORITY_NORMAL\x10\x02\x12\x11\n" +
+	"\rPRIORITY_HIGH\x10\x03\x12\x13\n" +
+	"\x0fPRIORITY_URGENT\x10\x04*\xaf\x01\n" +
+	"\vTaskOrderBy\x12\x1d\n" +
+	"\x19TASK_ORDER_BY_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14TASK_ORDER_BY_DUE_AT\x10\x01\x12\x1a\n" +
+	"\x16TASK_ORDER_BY_PRIORITY\x10\x02\x12\x14\n" +
+	"\x10TASK_ORDER_BY_ID\x10\x03\x12\x1c\n" +
+	"\x18TASK_ORDER_BY_CREATED_AT\x10\x04\x12\x17\n" +
+	"\x13TASK_ORDER_BY_TITLE\x10\x05*\x9a\x01\n" +
+	"\x13NotificationChannel\x12$\n" +
+	" NOTIFICATION_CHANNEL_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bNOTIFICATION_CHANNEL_IN_APP\x10\x01\x12\x1e\n" +
+	"\x1aNOTIFICATION_CHANNEL_EMAIL\x10\x02\x12\x1c\n" +
+	"\x18NOTIFICATION_CHANNEL_SMS\x10\x032\xfd\x17\n" +
 	"\fTasksService\x128\n" +
-	"\aGetTask\x12\x15.tasks.GetTaskRequest\x1a\x16.tasks.GetTaskResponse\x12D\n" +
+	"\aGetTask\x12\x15.tasks.GetTaskRequest\x1a\x16.tasks.GetTaskResponse\x12;\n" +
+	"\bGetTasks\x12\x16.tasks.GetTasksRequest\x1a\x17.tasks.GetTasksResponse\x12D\n" +
 	"\vGetTasksIDs\x12\x19.tasks.GetTasksIDsRequest\x1a\x1a.tasks.GetTasksIDsResponse\x12A\n" +
 	"\n" +
 	"CreateTask\x12\x18.tasks.CreateTaskRequest\x1a\x19.tasks.CreateTaskResponse\x12A\n" +
 	"\n" +
-	"DeleteTask\x12\x18.tasks.DeleteTaskRequest\x1a\x19.tasks.DeleteTaskResponse\x12A\n" +
+	"DeleteTask\x12\x18.tasks.DeleteTaskRequest\x1a\x19.tasks.DeleteTaskResponse\x12D\n" +
+	"\vRestoreTask\x12\x19.tasks.RestoreTaskRequest\x1a\x1a.tasks.RestoreTaskResponse\x12>\n" +
+	"\tPurgeTask\x12\x17.tasks.PurgeTaskRequest\x1a\x18.tasks.PurgeTaskResponse\x12A\n" +
 	"\n" +
 	"UpdateTask\x12\x18.tasks.UpdateTaskRequest\x1a\x19.tasks.UpdateTaskResponse\x12V\n" +
-	"\x11GetTasksByPatient\x12\x1f.tasks.GetTasksByPatientRequest\x1a .tasks.GetTasksByPatientResponseB8Z6github.com/TekClinic/Tasks-MicroService/tasks_protobufb\x06proto3"
+	"\x11GetTasksByPatient\x12\x1f.tasks.GetTasksByPatientRequest\x1a .tasks.GetTasksByPatientResponse\x12A\n" +
+	"\n" +
+	"AssignTask\x12\x18.tasks.AssignTaskRequest\x1a\x19.tasks.AssignTaskResponse\x12G\n" +
+	"\fUnassignTask\x12\x1a.tasks.UnassignTaskRequest\x1a\x1b.tasks.UnassignTaskResponse\x12J\n" +
+	"\rGetMyTasksIDs\x12\x1b.tasks.GetMyTasksIDsRequest\x1a\x1c.tasks.GetMyTasksIDsResponse\x12M\n" +
+	"\x0eTransitionTask\x12\x1c.tasks.TransitionTaskRequest\x1a\x1d.tasks.TransitionTaskResponse\x12M\n" +
+	"\x0eGetTaskHistory\x12\x1c.tasks.GetTaskHistoryRequest\x1a\x1d.tasks.GetTaskHistoryResponse\x12S\n" +
+	"\x10AddChecklistItem\x12\x1e.tasks.AddChecklistItemRequest\x1a\x1f.tasks.AddChecklistItemResponse\x12\\\n" +
+	"\x13UpdateChecklistItem\x12!.tasks.UpdateChecklistItemRequest\x1a\".tasks.UpdateChecklistItemResponse\x12\\\n" +
+	"\x13DeleteChecklistItem\x12!.tasks.DeleteChecklistItemRequest\x1a\".tasks.DeleteChecklistItemResponse\x12J\n" +
+	"\rAddDependency\x12\x1b.tasks.AddDependencyRequest\x1a\x1c.tasks.AddDependencyResponse\x12S\n" +
+	"\x10RemoveDependency\x12\x1e.tasks.RemoveDependencyRequest\x1a\x1f.tasks.RemoveDependencyResponse\x12Y\n" +
+	"\x12CreateTaskTemplate\x12 .tasks.CreateTaskTemplateRequest\x1a!.tasks.CreateTaskTemplateResponse\x12P\n" +
+	"\x0fGetTaskTemplate\x12\x1d.tasks.GetTaskTemplateRequest\x1a\x1e.tasks.GetTaskTemplateResponse\x12S\n" +
+	"\x10GetTaskTemplates\x12\x1e.tasks.GetTaskTemplatesRequest\x1a\x1f.tasks.GetTaskTemplatesResponse\x12Y\n" +
+	"\x12DeleteTaskTemplate\x12 .tasks.DeleteTaskTemplateRequest\x1a!.tasks.DeleteTaskTemplateResponse\x12A\n" +
+	"\n" +
+	"AddComment\x12\x18.tasks.AddCommentRequest\x1a\x19.tasks.AddCommentResponse\x12G\n" +
+	"\fListComments\x12\x1a.tasks.ListCommentsRequest\x1a\x1b.tasks.ListCommentsResponse\x12D\n" +
+	"\vEditComment\x12\x19.tasks.EditCommentRequest\x1a\x1a.tasks.EditCommentResponse\x12J\n" +
+	"\rDeleteComment\x12\x1b.tasks.DeleteCommentRequest\x1a\x1c.tasks.DeleteCommentResponse\x12:\n" +
+	"\n" +
+	"WatchTasks\x12\x18.tasks.WatchTasksRequest\x1a\x10.tasks.TaskEvent0\x01\x12J\n" +
+	"\rCreateWebhook\x12\x1b.tasks.CreateWebhookRequest\x1a\x1c.tasks.CreateWebhookResponse\x12D\n" +
+	"\vGetWebhooks\x12\x19.tasks.GetWebhooksRequest\x1a\x1a.tasks.GetWebhooksResponse\x12J\n" +
+	"\rDeleteWebhook\x12\x1b.tasks.DeleteWebhookRequest\x1a\x1c.tasks.DeleteWebhookResponse\x12b\n" +
+	"\x15ListWebhookDeliveries\x12#.tasks.ListWebhookDeliveriesRequest\x1a$.tasks.ListWebhookDeliveriesResponse\x12q\n" +
+	"\x1aGetNotificationPreferences\x12(.tasks.GetNotificationPreferencesRequest\x1a).tasks.GetNotificationPreferencesResponse\x12z\n" +
+	"\x1dUpdateNotificationPreferences\x12+.tasks.UpdateNotificationPreferencesRequest\x1a,.tasks.UpdateNotificationPreferencesResponse\x12Y\n" +
+	"\x12GetMyNotifications\x12 .tasks.GetMyNotificationsRequest\x1a!.tasks.GetMyNotificationsResponse\x12b\n" +
+	"\x15MarkNotificationsRead\x12#.tasks.MarkNotificationsReadRequest\x1a$.tasks.MarkNotificationsReadResponse\x12\\\n" +
+	"\x13ArchivePatientTasks\x12!.tasks.ArchivePatientTasksRequest\x1a\".tasks.ArchivePatientTasksResponse\x12\\\n" +
+	"\x13RestorePatientTasks\x12!.tasks.RestorePatientTasksRequest\x1a\".tasks.RestorePatientTasksResponseB8Z6github.com/TekClinic/Tasks-MicroService/tasks_protobufb\x06proto3"
 
 var (
 	file_tasks_service_proto_rawDescOnce sync.Once
@@ -804,43 +6139,230 @@ func file_tasks_service_proto_rawDescGZIP() []byte {
 	return file_tasks_service_proto_rawDescData
 }
 
-var file_tasks_service_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_tasks_service_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_tasks_service_proto_msgTypes = make([]protoimpl.MessageInfo, 87)
 var file_tasks_service_proto_goTypes = []any{
-	(*GetTaskRequest)(nil),            // 0: tasks.GetTaskRequest
-	(*GetTaskResponse)(nil),           // 1: tasks.GetTaskResponse
-	(*GetTasksIDsRequest)(nil),        // 2: tasks.GetTasksIDsRequest
-	(*GetTasksIDsResponse)(nil),       // 3: tasks.GetTasksIDsResponse
-	(*CreateTaskRequest)(nil),         // 4: tasks.CreateTaskRequest
-	(*CreateTaskResponse)(nil),        // 5: tasks.CreateTaskResponse
-	(*DeleteTaskRequest)(nil),         // 6: tasks.DeleteTaskRequest
-	(*DeleteTaskResponse)(nil),        // 7: tasks.DeleteTaskResponse
-	(*UpdateTaskRequest)(nil),         // 8: tasks.UpdateTaskRequest
-	(*UpdateTaskResponse)(nil),        // 9: tasks.UpdateTaskResponse
-	(*GetTasksByPatientRequest)(nil),  // 10: tasks.GetTasksByPatientRequest
-	(*GetTasksByPatientResponse)(nil), // 11: tasks.GetTasksByPatientResponse
-	(*Task)(nil),                      // 12: tasks.Task
+	(TaskOrderBy)(0),                              // 0: tasks.TaskOrderBy
+	(NotificationChannel)(0),                      // 1: tasks.NotificationChannel
+	(TaskEvent_Type)(0),                           // 2: tasks.TaskEvent.Type
+	(WebhookDelivery_State)(0),                    // 3: tasks.WebhookDelivery.State
+	(Task_Status)(0),                              // 4: tasks.Task.Status
+	(Task_Priority)(0),                            // 5: tasks.Task.Priority
+	(*GetTaskRequest)(nil),                        // 6: tasks.GetTaskRequest
+	(*GetTaskResponse)(nil),                       // 7: tasks.GetTaskResponse
+	(*GetTasksRequest)(nil),                       // 8: tasks.GetTasksRequest
+	(*GetTasksResponse)(nil),                      // 9: tasks.GetTasksResponse
+	(*GetTasksIDsRequest)(nil),                    // 10: tasks.GetTasksIDsRequest
+	(*TaskFilter)(nil),                            // 11: tasks.TaskFilter
+	(*GetTasksIDsResponse)(nil),                   // 12: tasks.GetTasksIDsResponse
+	(*CreateTaskRequest)(nil),                     // 13: tasks.CreateTaskRequest
+	(*CreateTaskResponse)(nil),                    // 14: tasks.CreateTaskResponse
+	(*DeleteTaskRequest)(nil),                     // 15: tasks.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),                    // 16: tasks.DeleteTaskResponse
+	(*RestoreTaskRequest)(nil),                    // 17: tasks.RestoreTaskRequest
+	(*RestoreTaskResponse)(nil),                   // 18: tasks.RestoreTaskResponse
+	(*PurgeTaskRequest)(nil),                      // 19: tasks.PurgeTaskRequest
+	(*PurgeTaskResponse)(nil),                     // 20: tasks.PurgeTaskResponse
+	(*UpdateTaskRequest)(nil),                     // 21: tasks.UpdateTaskRequest
+	(*UpdateTaskResponse)(nil),                    // 22: tasks.UpdateTaskResponse
+	(*GetTasksByPatientRequest)(nil),              // 23: tasks.GetTasksByPatientRequest
+	(*GetTasksByPatientResponse)(nil),             // 24: tasks.GetTasksByPatientResponse
+	(*AssignTaskRequest)(nil),                     // 25: tasks.AssignTaskRequest
+	(*AssignTaskResponse)(nil),                    // 26: tasks.AssignTaskResponse
+	(*UnassignTaskRequest)(nil),                   // 27: tasks.UnassignTaskRequest
+	(*UnassignTaskResponse)(nil),                  // 28: tasks.UnassignTaskResponse
+	(*GetMyTasksIDsRequest)(nil),                  // 29: tasks.GetMyTasksIDsRequest
+	(*GetMyTasksIDsResponse)(nil),                 // 30: tasks.GetMyTasksIDsResponse
+	(*TransitionTaskRequest)(nil),                 // 31: tasks.TransitionTaskRequest
+	(*TransitionTaskResponse)(nil),                // 32: tasks.TransitionTaskResponse
+	(*GetTaskHistoryRequest)(nil),                 // 33: tasks.GetTaskHistoryRequest
+	(*GetTaskHistoryResponse)(nil),                // 34: tasks.GetTaskHistoryResponse
+	(*TaskHistoryEntry)(nil),                      // 35: tasks.TaskHistoryEntry
+	(*TaskFieldChange)(nil),                       // 36: tasks.TaskFieldChange
+	(*AddChecklistItemRequest)(nil),               // 37: tasks.AddChecklistItemRequest
+	(*AddChecklistItemResponse)(nil),              // 38: tasks.AddChecklistItemResponse
+	(*UpdateChecklistItemRequest)(nil),            // 39: tasks.UpdateChecklistItemRequest
+	(*UpdateChecklistItemResponse)(nil),           // 40: tasks.UpdateChecklistItemResponse
+	(*DeleteChecklistItemRequest)(nil),            // 41: tasks.DeleteChecklistItemRequest
+	(*DeleteChecklistItemResponse)(nil),           // 42: tasks.DeleteChecklistItemResponse
+	(*AddDependencyRequest)(nil),                  // 43: tasks.AddDependencyRequest
+	(*AddDependencyResponse)(nil),                 // 44: tasks.AddDependencyResponse
+	(*RemoveDependencyRequest)(nil),               // 45: tasks.RemoveDependencyRequest
+	(*RemoveDependencyResponse)(nil),              // 46: tasks.RemoveDependencyResponse
+	(*CreateTaskTemplateRequest)(nil),             // 47: tasks.CreateTaskTemplateRequest
+	(*CreateTaskTemplateResponse)(nil),            // 48: tasks.CreateTaskTemplateResponse
+	(*GetTaskTemplateRequest)(nil),                // 49: tasks.GetTaskTemplateRequest
+	(*GetTaskTemplateResponse)(nil),               // 50: tasks.GetTaskTemplateResponse
+	(*GetTaskTemplatesRequest)(nil),               // 51: tasks.GetTaskTemplatesRequest
+	(*GetTaskTemplatesResponse)(nil),              // 52: tasks.GetTaskTemplatesResponse
+	(*DeleteTaskTemplateRequest)(nil),             // 53: tasks.DeleteTaskTemplateRequest
+	(*DeleteTaskTemplateResponse)(nil),            // 54: tasks.DeleteTaskTemplateResponse
+	(*AddCommentRequest)(nil),                     // 55: tasks.AddCommentRequest
+	(*AddCommentResponse)(nil),                    // 56: tasks.AddCommentResponse
+	(*ListCommentsRequest)(nil),                   // 57: tasks.ListCommentsRequest
+	(*ListCommentsResponse)(nil),                  // 58: tasks.ListCommentsResponse
+	(*EditCommentRequest)(nil),                    // 59: tasks.EditCommentRequest
+	(*EditCommentResponse)(nil),                   // 60: tasks.EditCommentResponse
+	(*DeleteCommentRequest)(nil),                  // 61: tasks.DeleteCommentRequest
+	(*DeleteCommentResponse)(nil),                 // 62: tasks.DeleteCommentResponse
+	(*Comment)(nil),                               // 63: tasks.Comment
+	(*WatchTasksRequest)(nil),                     // 64: tasks.WatchTasksRequest
+	(*TaskEvent)(nil),                             // 65: tasks.TaskEvent
+	(*CreateWebhookRequest)(nil),                  // 66: tasks.CreateWebhookRequest
+	(*CreateWebhookResponse)(nil),                 // 67: tasks.CreateWebhookResponse
+	(*GetWebhooksRequest)(nil),                    // 68: tasks.GetWebhooksRequest
+	(*GetWebhooksResponse)(nil),                   // 69: tasks.GetWebhooksResponse
+	(*DeleteWebhookRequest)(nil),                  // 70: tasks.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),                 // 71: tasks.DeleteWebhookResponse
+	(*ListWebhookDeliveriesRequest)(nil),          // 72: tasks.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil),         // 73: tasks.ListWebhookDeliveriesResponse
+	(*Webhook)(nil),                               // 74: tasks.Webhook
+	(*WebhookDelivery)(nil),                       // 75: tasks.WebhookDelivery
+	(*GetNotificationPreferencesRequest)(nil),     // 76: tasks.GetNotificationPreferencesRequest
+	(*GetNotificationPreferencesResponse)(nil),    // 77: tasks.GetNotificationPreferencesResponse
+	(*UpdateNotificationPreferencesRequest)(nil),  // 78: tasks.UpdateNotificationPreferencesRequest
+	(*UpdateNotificationPreferencesResponse)(nil), // 79: tasks.UpdateNotificationPreferencesResponse
+	(*GetMyNotificationsRequest)(nil),             // 80: tasks.GetMyNotificationsRequest
+	(*GetMyNotificationsResponse)(nil),            // 81: tasks.GetMyNotificationsResponse
+	(*MarkNotificationsReadRequest)(nil),          // 82: tasks.MarkNotificationsReadRequest
+	(*MarkNotificationsReadResponse)(nil),         // 83: tasks.MarkNotificationsReadResponse
+	(*ArchivePatientTasksRequest)(nil),            // 84: tasks.ArchivePatientTasksRequest
+	(*ArchivePatientTasksResponse)(nil),           // 85: tasks.ArchivePatientTasksResponse
+	(*RestorePatientTasksRequest)(nil),            // 86: tasks.RestorePatientTasksRequest
+	(*RestorePatientTasksResponse)(nil),           // 87: tasks.RestorePatientTasksResponse
+	(*NotificationPreferences)(nil),               // 88: tasks.NotificationPreferences
+	(*Notification)(nil),                          // 89: tasks.Notification
+	(*TaskTemplate)(nil),                          // 90: tasks.TaskTemplate
+	(*ChecklistItem)(nil),                         // 91: tasks.ChecklistItem
+	(*Task)(nil),                                  // 92: tasks.Task
+	(*fieldmaskpb.FieldMask)(nil),                 // 93: google.protobuf.FieldMask
+	(*structpb.Value)(nil),                        // 94: google.protobuf.Value
 }
 var file_tasks_service_proto_depIdxs = []int32{
-	12, // 0: tasks.GetTaskResponse.task:type_name -> tasks.Task
-	12, // 1: tasks.UpdateTaskRequest.task:type_name -> tasks.Task
-	12, // 2: tasks.GetTasksByPatientResponse.tasks:type_name -> tasks.Task
-	0,  // 3: tasks.TasksService.GetTask:input_type -> tasks.GetTaskRequest
-	2,  // 4: tasks.TasksService.GetTasksIDs:input_type -> tasks.GetTasksIDsRequest
-	4,  // 5: tasks.TasksService.CreateTask:input_type -> tasks.CreateTaskRequest
-	6,  // 6: tasks.TasksService.DeleteTask:input_type -> tasks.DeleteTaskRequest
-	8,  // 7: tasks.TasksService.UpdateTask:input_type -> tasks.UpdateTaskRequest
-	10, // 8: tasks.TasksService.GetTasksByPatient:input_type -> tasks.GetTasksByPatientRequest
-	1,  // 9: tasks.TasksService.GetTask:output_type -> tasks.GetTaskResponse
-	3,  // 10: tasks.TasksService.GetTasksIDs:output_type -> tasks.GetTasksIDsResponse
-	5,  // 11: tasks.TasksService.CreateTask:output_type -> tasks.CreateTaskResponse
-	7,  // 12: tasks.TasksService.DeleteTask:output_type -> tasks.DeleteTaskResponse
-	9,  // 13: tasks.TasksService.UpdateTask:output_type -> tasks.UpdateTaskResponse
-	11, // 14: tasks.TasksService.GetTasksByPatient:output_type -> tasks.GetTasksByPatientResponse
-	9,  // [9:15] is the sub-list for method output_type
-	3,  // [3:9] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	92, // 0: tasks.GetTaskResponse.task:type_name -> tasks.Task
+	92, // 1: tasks.GetTasksResponse.tasks:type_name -> tasks.Task
+	5,  // 2: tasks.GetTasksIDsRequest.priority:type_name -> tasks.Task.Priority
+	0,  // 3: tasks.GetTasksIDsRequest.order_by:type_name -> tasks.TaskOrderBy
+	11, // 4: tasks.GetTasksIDsRequest.filter:type_name -> tasks.TaskFilter
+	5,  // 5: tasks.CreateTaskRequest.priority:type_name -> tasks.Task.Priority
+	92, // 6: tasks.UpdateTaskRequest.task:type_name -> tasks.Task
+	93, // 7: tasks.UpdateTaskRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 8: tasks.GetTasksByPatientRequest.order_by:type_name -> tasks.TaskOrderBy
+	92, // 9: tasks.GetTasksByPatientResponse.tasks:type_name -> tasks.Task
+	4,  // 10: tasks.TransitionTaskRequest.status:type_name -> tasks.Task.Status
+	92, // 11: tasks.TransitionTaskResponse.task:type_name -> tasks.Task
+	35, // 12: tasks.GetTaskHistoryResponse.entries:type_name -> tasks.TaskHistoryEntry
+	36, // 13: tasks.TaskHistoryEntry.changes:type_name -> tasks.TaskFieldChange
+	94, // 14: tasks.TaskFieldChange.before:type_name -> google.protobuf.Value
+	94, // 15: tasks.TaskFieldChange.after:type_name -> google.protobuf.Value
+	91, // 16: tasks.AddChecklistItemResponse.item:type_name -> tasks.ChecklistItem
+	91, // 17: tasks.UpdateChecklistItemRequest.item:type_name -> tasks.ChecklistItem
+	93, // 18: tasks.UpdateChecklistItemRequest.update_mask:type_name -> google.protobuf.FieldMask
+	91, // 19: tasks.UpdateChecklistItemResponse.item:type_name -> tasks.ChecklistItem
+	90, // 20: tasks.CreateTaskTemplateRequest.template:type_name -> tasks.TaskTemplate
+	90, // 21: tasks.GetTaskTemplateResponse.template:type_name -> tasks.TaskTemplate
+	90, // 22: tasks.GetTaskTemplatesResponse.templates:type_name -> tasks.TaskTemplate
+	63, // 23: tasks.AddCommentResponse.comment:type_name -> tasks.Comment
+	63, // 24: tasks.ListCommentsResponse.comments:type_name -> tasks.Comment
+	63, // 25: tasks.EditCommentResponse.comment:type_name -> tasks.Comment
+	2,  // 26: tasks.TaskEvent.type:type_name -> tasks.TaskEvent.Type
+	2,  // 27: tasks.CreateWebhookRequest.event_types:type_name -> tasks.TaskEvent.Type
+	74, // 28: tasks.GetWebhooksResponse.webhooks:type_name -> tasks.Webhook
+	3,  // 29: tasks.ListWebhookDeliveriesRequest.state:type_name -> tasks.WebhookDelivery.State
+	75, // 30: tasks.ListWebhookDeliveriesResponse.deliveries:type_name -> tasks.WebhookDelivery
+	2,  // 31: tasks.Webhook.event_types:type_name -> tasks.TaskEvent.Type
+	2,  // 32: tasks.WebhookDelivery.event_type:type_name -> tasks.TaskEvent.Type
+	3,  // 33: tasks.WebhookDelivery.state:type_name -> tasks.WebhookDelivery.State
+	88, // 34: tasks.GetNotificationPreferencesResponse.preferences:type_name -> tasks.NotificationPreferences
+	88, // 35: tasks.UpdateNotificationPreferencesRequest.preferences:type_name -> tasks.NotificationPreferences
+	88, // 36: tasks.UpdateNotificationPreferencesResponse.preferences:type_name -> tasks.NotificationPreferences
+	89, // 37: tasks.GetMyNotificationsResponse.notifications:type_name -> tasks.Notification
+	1,  // 38: tasks.NotificationPreferences.channels:type_name -> tasks.NotificationChannel
+	5,  // 39: tasks.TaskTemplate.priority:type_name -> tasks.Task.Priority
+	4,  // 40: tasks.Task.status:type_name -> tasks.Task.Status
+	5,  // 41: tasks.Task.priority:type_name -> tasks.Task.Priority
+	91, // 42: tasks.Task.checklist:type_name -> tasks.ChecklistItem
+	6,  // 43: tasks.TasksService.GetTask:input_type -> tasks.GetTaskRequest
+	8,  // 44: tasks.TasksService.GetTasks:input_type -> tasks.GetTasksRequest
+	10, // 45: tasks.TasksService.GetTasksIDs:input_type -> tasks.GetTasksIDsRequest
+	13, // 46: tasks.TasksService.CreateTask:input_type -> tasks.CreateTaskRequest
+	15, // 47: tasks.TasksService.DeleteTask:input_type -> tasks.DeleteTaskRequest
+	17, // 48: tasks.TasksService.RestoreTask:input_type -> tasks.RestoreTaskRequest
+	19, // 49: tasks.TasksService.PurgeTask:input_type -> tasks.PurgeTaskRequest
+	21, // 50: tasks.TasksService.UpdateTask:input_type -> tasks.UpdateTaskRequest
+	23, // 51: tasks.TasksService.GetTasksByPatient:input_type -> tasks.GetTasksByPatientRequest
+	25, // 52: tasks.TasksService.AssignTask:input_type -> tasks.AssignTaskRequest
+	27, // 53: tasks.TasksService.UnassignTask:input_type -> tasks.UnassignTaskRequest
+	29, // 54: tasks.TasksService.GetMyTasksIDs:input_type -> tasks.GetMyTasksIDsRequest
+	31, // 55: tasks.TasksService.TransitionTask:input_type -> tasks.TransitionTaskRequest
+	33, // 56: tasks.TasksService.GetTaskHistory:input_type -> tasks.GetTaskHistoryRequest
+	37, // 57: tasks.TasksService.AddChecklistItem:input_type -> tasks.AddChecklistItemRequest
+	39, // 58: tasks.TasksService.UpdateChecklistItem:input_type -> tasks.UpdateChecklistItemRequest
+	41, // 59: tasks.TasksService.DeleteChecklistItem:input_type -> tasks.DeleteChecklistItemRequest
+	43, // 60: tasks.TasksService.AddDependency:input_type -> tasks.AddDependencyRequest
+	45, // 61: tasks.TasksService.RemoveDependency:input_type -> tasks.RemoveDependencyRequest
+	47, // 62: tasks.TasksService.CreateTaskTemplate:input_type -> tasks.CreateTaskTemplateRequest
+	49, // 63: tasks.TasksService.GetTaskTemplate:input_type -> tasks.GetTaskTemplateRequest
+	51, // 64: tasks.TasksService.GetTaskTemplates:input_type -> tasks.GetTaskTemplatesRequest
+	53, // 65: tasks.TasksService.DeleteTaskTemplate:input_type -> tasks.DeleteTaskTemplateRequest
+	55, // 66: tasks.TasksService.AddComment:input_type -> tasks.AddCommentRequest
+	57, // 67: tasks.TasksService.ListComments:input_type -> tasks.ListCommentsRequest
+	59, // 68: tasks.TasksService.EditComment:input_type -> tasks.EditCommentRequest
+	61, // 69: tasks.TasksService.DeleteComment:input_type -> tasks.DeleteCommentRequest
+	64, // 70: tasks.TasksService.WatchTasks:input_type -> tasks.WatchTasksRequest
+	66, // 71: tasks.TasksService.CreateWebhook:input_type -> tasks.CreateWebhookRequest
+	68, // 72: tasks.TasksService.GetWebhooks:input_type -> tasks.GetWebhooksRequest
+	70, // 73: tasks.TasksService.DeleteWebhook:input_type -> tasks.DeleteWebhookRequest
+	72, // 74: tasks.TasksService.ListWebhookDeliveries:input_type -> tasks.ListWebhookDeliveriesRequest
+	76, // 75: tasks.TasksService.GetNotificationPreferences:input_type -> tasks.GetNotificationPreferencesRequest
+	78, // 76: tasks.TasksService.UpdateNotificationPreferences:input_type -> tasks.UpdateNotificationPreferencesRequest
+	80, // 77: tasks.TasksService.GetMyNotifications:input_type -> tasks.GetMyNotificationsRequest
+	82, // 78: tasks.TasksService.MarkNotificationsRead:input_type -> tasks.MarkNotificationsReadRequest
+	84, // 79: tasks.TasksService.ArchivePatientTasks:input_type -> tasks.ArchivePatientTasksRequest
+	86, // 80: tasks.TasksService.RestorePatientTasks:input_type -> tasks.RestorePatientTasksRequest
+	7,  // 81: tasks.TasksService.GetTask:output_type -> tasks.GetTaskResponse
+	9,  // 82: tasks.TasksService.GetTasks:output_type -> tasks.GetTasksResponse
+	12, // 83: tasks.TasksService.GetTasksIDs:output_type -> tasks.GetTasksIDsResponse
+	14, // 84: tasks.TasksService.CreateTask:output_type -> tasks.CreateTaskResponse
+	16, // 85: tasks.TasksService.DeleteTask:output_type -> tasks.DeleteTaskResponse
+	18, // 86: tasks.TasksService.RestoreTask:output_type -> tasks.RestoreTaskResponse
+	20, // 87: tasks.TasksService.PurgeTask:output_type -> tasks.PurgeTaskResponse
+	22, // 88: tasks.TasksService.UpdateTask:output_type -> tasks.UpdateTaskResponse
+	24, // 89: tasks.TasksService.GetTasksByPatient:output_type -> tasks.GetTasksByPatientResponse
+	26, // 90: tasks.TasksService.AssignTask:output_type -> tasks.AssignTaskResponse
+	28, // 91: tasks.TasksService.UnassignTask:output_type -> tasks.UnassignTaskResponse
+	30, // 92: tasks.TasksService.GetMyTasksIDs:output_type -> tasks.GetMyTasksIDsResponse
+	32, // 93: tasks.TasksService.TransitionTask:output_type -> tasks.TransitionTaskResponse
+	34, // 94: tasks.TasksService.GetTaskHistory:output_type -> tasks.GetTaskHistoryResponse
+	38, // 95: tasks.TasksService.AddChecklistItem:output_type -> tasks.AddChecklistItemResponse
+	40, // 96: tasks.TasksService.UpdateChecklistItem:output_type -> tasks.UpdateChecklistItemResponse
+	42, // 97: tasks.TasksService.DeleteChecklistItem:output_type -> tasks.DeleteChecklistItemResponse
+	44, // 98: tasks.TasksService.AddDependency:output_type -> tasks.AddDependencyResponse
+	46, // 99: tasks.TasksService.RemoveDependency:output_type -> tasks.RemoveDependencyResponse
+	48, // 100: tasks.TasksService.CreateTaskTemplate:output_type -> tasks.CreateTaskTemplateResponse
+	50, // 101: tasks.TasksService.GetTaskTemplate:output_type -> tasks.GetTaskTemplateResponse
+	52, // 102: tasks.TasksService.GetTaskTemplates:output_type -> tasks.GetTaskTemplatesResponse
+	54, // 103: tasks.TasksService.DeleteTaskTemplate:output_type -> tasks.DeleteTaskTemplateResponse
+	56, // 104: tasks.TasksService.AddComment:output_type -> tasks.AddCommentResponse
+	58, // 105: tasks.TasksService.ListComments:output_type -> tasks.ListCommentsResponse
+	60, // 106: tasks.TasksService.EditComment:output_type -> tasks.EditCommentResponse
+	62, // 107: tasks.TasksService.DeleteComment:output_type -> tasks.DeleteCommentResponse
+	65, // 108: tasks.TasksService.WatchTasks:output_type -> tasks.TaskEvent
+	67, // 109: tasks.TasksService.CreateWebhook:output_type -> tasks.CreateWebhookResponse
+	69, // 110: tasks.TasksService.GetWebhooks:output_type -> tasks.GetWebhooksResponse
+	71, // 111: tasks.TasksService.DeleteWebhook:output_type -> tasks.DeleteWebhookResponse
+	73, // 112: tasks.TasksService.ListWebhookDeliveries:output_type -> tasks.ListWebhookDeliveriesResponse
+	77, // 113: tasks.TasksService.GetNotificationPreferences:output_type -> tasks.GetNotificationPreferencesResponse
+	79, // 114: tasks.TasksService.UpdateNotificationPreferences:output_type -> tasks.UpdateNotificationPreferencesResponse
+	81, // 115: tasks.TasksService.GetMyNotifications:output_type -> tasks.GetMyNotificationsResponse
+	83, // 116: tasks.TasksService.MarkNotificationsRead:output_type -> tasks.MarkNotificationsReadResponse
+	85, // 117: tasks.TasksService.ArchivePatientTasks:output_type -> tasks.ArchivePatientTasksResponse
+	87, // 118: tasks.TasksService.RestorePatientTasks:output_type -> tasks.RestorePatientTasksResponse
+	81, // [81:119] is the sub-list for method output_type
+	43, // [43:81] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
 }
 
 func init() { file_tasks_service_proto_init() }
@@ -848,18 +6370,21 @@ func file_tasks_service_proto_init() {
 	if File_tasks_service_proto != nil {
 		return
 	}
+	file_tasks_service_proto_msgTypes[5].OneofWrappers = []any{}
+	file_tasks_service_proto_msgTypes[17].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tasks_service_proto_rawDesc), len(file_tasks_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      6,
+			NumMessages:   87,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_tasks_service_proto_goTypes,
 		DependencyIndexes: file_tasks_service_proto_depIdxs,
+		EnumInfos:         file_tasks_service_proto_enumTypes,
 		MessageInfos:      file_tasks_service_proto_msgTypes,
 	}.Build()
 	File_tasks_service_proto = out.File